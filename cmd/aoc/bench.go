@@ -0,0 +1,151 @@
+package main
+
+import (
+	"aoc2020/solver"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+)
+
+func bench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input file, or - for stdin (defaults to the embedded input)")
+	runs := fs.Int("n", 10, "number of times to run each day")
+	savePath := fs.String("save", "", "write the results to this file for use as a baseline")
+	baselinePath := fs.String("baseline", "", "compare against results saved with -save")
+	maxRegression := fs.Float64("max-regression", 0, "fail if any median is this many percent slower than the baseline (0 to never fail)")
+	fs.Parse(args)
+
+	spec := "all"
+	if fs.NArg() == 1 {
+		spec = fs.Arg(0)
+	} else if fs.NArg() > 1 {
+		return errors.New("bench: expected a single day, range of days, or \"all\"")
+	}
+
+	solvers, err := registry.Select(spec)
+	if err != nil {
+		return err
+	}
+
+	if *inputPath != "" && len(solvers) > 1 {
+		return errors.New("bench: -input can only be used with a single day")
+	}
+
+	var baseline map[statsKey]solver.Stats
+	if *baselinePath != "" {
+		baseline, err = loadBaseline(*baselinePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	var all []solver.Stats
+	for _, s := range solvers {
+		input, err := readInput(s, *inputPath)
+		if err != nil {
+			return fmt.Errorf("day %v: %v", s.Day, err)
+		}
+
+		stats, err := s.Benchmark(input, *runs)
+		if err != nil {
+			return err
+		}
+
+		all = append(all, stats...)
+	}
+
+	regressed := printStats(all, baseline, *maxRegression)
+
+	if *savePath != "" {
+		data, err := json.MarshalIndent(all, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(*savePath, append(data, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+
+	if regressed {
+		return fmt.Errorf("one or more medians regressed by more than %v%%", *maxRegression)
+	}
+
+	return nil
+}
+
+type statsKey struct {
+	day   int
+	stage string
+}
+
+func loadBaseline(path string) (map[statsKey]solver.Stats, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []solver.Stats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	baseline := map[statsKey]solver.Stats{}
+	for _, s := range stats {
+		baseline[statsKey{s.Day, s.Stage}] = s
+	}
+
+	return baseline, nil
+}
+
+func readInput(s solver.Solver, inputPath string) ([]byte, error) {
+	r, err := s.Open(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+// printStats writes the stats as a table, with a column comparing each median
+// to the baseline when there is one. It reports whether any median regressed
+// by more than maxRegression percent.
+func printStats(all []solver.Stats, baseline map[statsKey]solver.Stats, maxRegression float64) bool {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	defer w.Flush()
+
+	header := "Day\tStage\tMin\tMedian\tP95\tAllocs/run\tBytes/run\t"
+	if baseline != nil {
+		header += "Baseline\tChange\t"
+	}
+	fmt.Fprintln(w, header)
+
+	regressed := false
+	for _, s := range all {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t", s.Day, s.Stage, s.Min, s.Median, s.P95, s.AllocsPerRun, s.BytesPerRun)
+
+		if baseline != nil {
+			base, ok := baseline[statsKey{s.Day, s.Stage}]
+			if !ok || base.Median == 0 {
+				fmt.Fprint(w, "-\t-\t")
+			} else {
+				change := float64(s.Median-base.Median) / float64(base.Median) * 100
+				fmt.Fprintf(w, "%v\t%+.1f%%\t", base.Median, change)
+
+				if maxRegression > 0 && change > maxRegression {
+					regressed = true
+				}
+			}
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	return regressed
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"aoc2020/solver"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// resultWriter writes solver results in one of the formats accepted by
+// -format. Flush must be called once all results have been written.
+type resultWriter interface {
+	Write(r solver.Result) error
+	Flush() error
+}
+
+// record is a Result flattened for machine-readable output.
+type record struct {
+	Day       int    `json:"day"`
+	Part      int    `json:"part"`
+	Answer    string `json:"answer"`
+	ElapsedNs int64  `json:"elapsed_ns"`
+	InputHash string `json:"input_sha256"`
+	Error     string `json:"error"`
+}
+
+func newRecord(r solver.Result) record {
+	rec := record{
+		Day:       r.Day,
+		Part:      r.Part,
+		ElapsedNs: r.Elapsed.Nanoseconds(),
+		InputHash: r.InputHash,
+	}
+
+	if r.Err != nil {
+		rec.Error = r.Err.Error()
+	} else {
+		rec.Answer = fmt.Sprint(r.Answer)
+	}
+
+	return rec
+}
+
+func newResultWriter(format string, w io.Writer) (resultWriter, error) {
+	switch format {
+	case "text":
+		return &textWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w, records: []record{}}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want text, json or csv)", format)
+	}
+}
+
+type textWriter struct {
+	w       io.Writer
+	lastDay int
+}
+
+func (tw *textWriter) Write(r solver.Result) error {
+	if r.Day != tw.lastDay {
+		if _, err := fmt.Fprintf(tw.w, "Day %v\n", r.Day); err != nil {
+			return err
+		}
+		tw.lastDay = r.Day
+	}
+
+	if r.Err != nil {
+		_, err := fmt.Fprintf(tw.w, "  Part %v: error: %v\n", r.Part, r.Err)
+		return err
+	}
+
+	_, err := fmt.Fprintf(tw.w, "  Part %v: %v (%v)\n", r.Part, r.Answer, r.Elapsed)
+	return err
+}
+
+func (tw *textWriter) Flush() error {
+	return nil
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	records []record
+}
+
+func (jw *jsonWriter) Write(r solver.Result) error {
+	jw.records = append(jw.records, newRecord(r))
+	return nil
+}
+
+func (jw *jsonWriter) Flush() error {
+	enc := json.NewEncoder(jw.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jw.records)
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (cw *csvWriter) Write(r solver.Result) error {
+	if !cw.headerWritten {
+		if err := cw.w.Write([]string{"day", "part", "answer", "elapsed_ns", "input_sha256", "error"}); err != nil {
+			return err
+		}
+		cw.headerWritten = true
+	}
+
+	rec := newRecord(r)
+
+	return cw.w.Write([]string{
+		strconv.Itoa(rec.Day),
+		strconv.Itoa(rec.Part),
+		rec.Answer,
+		strconv.FormatInt(rec.ElapsedNs, 10),
+		rec.InputHash,
+		rec.Error,
+	})
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"aoc2020/solver"
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_resultWriter(t *testing.T) {
+	results := []solver.Result{
+		{Day: 1, Part: 1, Answer: 514579, Elapsed: 1500 * time.Nanosecond, InputHash: "abc"},
+		{Day: 1, Part: 2, Err: errors.New("no match found"), InputHash: "abc"},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "text",
+			want:   "Day 1\n  Part 1: 514579 (1.5µs)\n  Part 2: error: no match found\n",
+		},
+		{
+			format: "csv",
+			want: "day,part,answer,elapsed_ns,input_sha256,error\n" +
+				"1,1,514579,1500,abc,\n" +
+				"1,2,,0,abc,no match found\n",
+		},
+		{
+			format: "json",
+			want: `[
+  {
+    "day": 1,
+    "part": 1,
+    "answer": "514579",
+    "elapsed_ns": 1500,
+    "input_sha256": "abc",
+    "error": ""
+  },
+  {
+    "day": 1,
+    "part": 2,
+    "answer": "",
+    "elapsed_ns": 0,
+    "input_sha256": "abc",
+    "error": "no match found"
+  }
+]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w, err := newResultWriter(tt.format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, r := range results {
+				if err := w.Write(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = \n%v\nwant:\n%v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"aoc2020/day01"
+	"aoc2020/utils/fileinput"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ksum finds entries in a day 1 style expense report adding up to a target,
+// for reconciling exports where the group size isn't always two or three.
+func ksum(args []string) error {
+	fs := flag.NewFlagSet("ksum", flag.ExitOnError)
+	inputPath := fs.String("input", "", "expense report, or - for stdin (defaults to day 1's embedded input)")
+	k := fs.Int("k", 2, "number of entries to find")
+	target := fs.String("target", "2020", "sum the entries must add up to")
+	all := fs.Bool("all", false, "list every matching combination, not just the first")
+	reuse := fs.Bool("reuse", false, "allow an entry to be used more than once in a combination")
+	useBig := fs.Bool("big", false, "use arbitrary-precision sums and products, for entries or products too large for an int")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("ksum: unexpected arguments")
+	}
+
+	if *useBig && (*all || *reuse) {
+		return errors.New("ksum: -all and -reuse can't be used with -big")
+	}
+
+	r, err := day01.Solver.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if *useBig {
+		return ksumBig(r, *k, *target)
+	}
+
+	t, err := strconv.Atoi(*target)
+	if err != nil {
+		return fmt.Errorf("ksum: invalid target %q, try -big for targets too large for an int", *target)
+	}
+
+	input, err := day01.Solver.Parse(r)
+	if err != nil {
+		return err
+	}
+
+	nums := input.([]int)
+
+	var matches []day01.Match
+	if *all || *reuse {
+		matches, err = day01.KSumAll(nums, *k, t, *reuse)
+		if err == nil && !*all {
+			matches = matches[:1]
+		}
+	} else {
+		var match day01.Match
+		match, err = day01.KSum(nums, *k, t)
+		matches = []day01.Match{match}
+	}
+	if err != nil {
+		return overflowHint(err)
+	}
+
+	for _, match := range matches {
+		product, err := match.Product()
+		if err != nil {
+			return overflowHint(err)
+		}
+
+		printMatch(match.Entries, match.Indices, *target, product)
+	}
+
+	if *all {
+		fmt.Printf("%v combinations\n", len(matches))
+	}
+
+	return nil
+}
+
+func ksumBig(r io.Reader, k int, target string) error {
+	t, ok := new(big.Int).SetString(target, 10)
+	if !ok {
+		return fmt.Errorf("ksum: invalid target %q", target)
+	}
+
+	nums, err := fileinput.ReadBigInts(r)
+	if err != nil {
+		return err
+	}
+
+	match, err := day01.KSumBig(nums, k, t)
+	if err != nil {
+		return err
+	}
+
+	printMatch(match.Entries, match.Indices, target, match.Product())
+
+	return nil
+}
+
+// overflowHint points towards -big when a search or product overflowed.
+func overflowHint(err error) error {
+	if errors.Is(err, day01.ErrOverflow) {
+		return fmt.Errorf("%w (try -big)", err)
+	}
+	return err
+}
+
+// printMatch shows a match's entries along with their 1-based positions in the
+// expense report, so they can be traced back to the export.
+func printMatch(entries interface{}, indices []int, target string, product interface{}) {
+	var terms []string
+	for i, index := range indices {
+		var entry interface{}
+		switch e := entries.(type) {
+		case []int:
+			entry = e[i]
+		case []*big.Int:
+			entry = e[i]
+		}
+
+		terms = append(terms, fmt.Sprintf("%v (#%v)", entry, index+1))
+	}
+
+	fmt.Printf("%v = %v, product %v\n", strings.Join(terms, " + "), target, product)
+}
@@ -0,0 +1,139 @@
+// Command aoc runs the Advent of Code 2020 solvers.
+//
+// Usage:
+//
+//	aoc run [-input file] [-format text|json|csv] <day|from-to|all>
+//	aoc verify [-input file] [-answers file] [day|from-to|all]
+//	aoc bench [-input file] [-n runs] [-save file] [-baseline file] [-max-regression percent] [day|from-to|all]
+//	aoc ksum [-input file] [-k entries] [-target sum] [-all] [-reuse] [-big]
+//	aoc passwords [-input file] [-lenient [-warnings file]] [-policy name[:arg]]... [-report [-format text|json|csv]]
+//	aoc slopes [-input file] [-min-down n] [-max-down n] [-min-right n] [-max-right n] [-most] [-all]
+//	aoc render [-input file] [-slope right,down]... [-format ascii|svg]
+//	aoc traverse [-input file] [-legend file] [-slope right,down]...
+//	aoc passports [-input file] [-schema file] [-lenient] [-details] [-workers n] [-print-schema] [-generate n [-seed n]]
+//	aoc serve [-addr host:port] [-schema file]
+//
+// Each day uses its embedded puzzle input unless -input names another file, or
+// "-" to read from stdin.
+//
+// run prints each part's answer and timing, or with -format json or csv, a
+// record per part including the input's SHA-256 and any error.
+//
+// verify checks each part's answer against the answers manifest for the input,
+// exiting non-zero if any part doesn't match, is missing from an -answers
+// manifest, or if no part was checked at all.
+//
+// bench runs each day repeatedly and prints timings and allocations for parsing
+// and each part, optionally compared to a baseline saved by an earlier run.
+//
+// ksum finds k entries in a day 1 expense report that add up to the target.
+//
+// passwords counts the valid passwords in a day 2 password database under each
+// -policy, defaulting to the two policies from the puzzle. With -report, it
+// lists every entry instead, with whether it passed each policy and why not.
+// A malformed line is an error unless -lenient is given, in which case it's
+// skipped with a warning, which a JSON report also lists and -warnings writes
+// to a CSV file.
+//
+// slopes searches every slope within the bounds for those hitting the fewest
+// trees on a day 3 map, or the most with -most. Ties share a rank, and -all
+// shows every slope searched.
+//
+// render prints a day 3 map with the cells visited at a slope marked, O where
+// open and X at a tree, or with -format svg, draws the paths for several
+// slopes, each in its own colour.
+//
+// traverse counts the cells of each terrain visited at each slope, and what
+// they cost. A -legend file maps other characters to terrains and costs, one
+// "char name cost" line each, for maps with more than open squares and trees.
+//
+// passports counts the day 4 passports with every required field, and those
+// that are fully valid, under a JSON -schema describing each field's type and
+// bounds, and tallies the problems across the batch. -details lists every
+// missing or invalid field of each invalid passport. -print-schema prints the
+// puzzle's rules in schema form. A malformed field, or one that's duplicated or
+// not in the schema, stops the run with its record and line, unless -lenient
+// is given, in which case each is listed as a warning. -workers validates the
+// batch across that many goroutines as it's read, for batches too large to hold
+// in memory, and -generate writes a made-up batch of any size to try it on.
+//
+// serve validates batches of passports POSTed to /validate over HTTP, as
+// "key:value" text or a JSON array of objects, replying with JSON giving each
+// passport's problems and the tallies for the batch. Text batches are parsed
+// strictly, unless the request's lenient query parameter is true.
+package main
+
+import (
+	"aoc2020/day01"
+	"aoc2020/day02"
+	"aoc2020/day03"
+	"aoc2020/day04"
+	"aoc2020/day05"
+	"aoc2020/day06"
+	"aoc2020/day07"
+	"aoc2020/day08"
+	"aoc2020/day09"
+	"aoc2020/day10"
+	"aoc2020/day11"
+	"aoc2020/solver"
+	"fmt"
+	"os"
+)
+
+var registry = solver.NewRegistry(
+	day01.Solver,
+	day02.Solver,
+	day03.Solver,
+	day04.Solver,
+	day05.Solver,
+	day06.Solver,
+	day07.Solver,
+	day08.Solver,
+	day09.Solver,
+	day10.Solver,
+	day11.Solver,
+)
+
+var commands = map[string]func(args []string) error{
+	"bench":     bench,
+	"ksum":      ksum,
+	"passports": passports,
+	"passwords": passwords,
+	"render":    render,
+	"run":       run,
+	"serve":     serve,
+	"slopes":    slopes,
+	"traverse":  traverse,
+	"verify":    verify,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := command(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc run [-input file] [-format text|json|csv] <day|from-to|all>")
+	fmt.Fprintln(os.Stderr, "       aoc verify [-input file] [-answers file] [day|from-to|all]")
+	fmt.Fprintln(os.Stderr, "       aoc bench [-input file] [-n runs] [-save file] [-baseline file] [-max-regression percent] [day|from-to|all]")
+	fmt.Fprintln(os.Stderr, "       aoc ksum [-input file] [-k entries] [-target sum] [-all] [-reuse] [-big]")
+	fmt.Fprintln(os.Stderr, "       aoc passwords [-input file] [-lenient [-warnings file]] [-policy name[:arg]]... [-report [-format text|json|csv]]")
+	fmt.Fprintln(os.Stderr, "       aoc slopes [-input file] [-min-down n] [-max-down n] [-min-right n] [-max-right n] [-most] [-all]")
+	fmt.Fprintln(os.Stderr, "       aoc render [-input file] [-slope right,down]... [-format ascii|svg]")
+	fmt.Fprintln(os.Stderr, "       aoc traverse [-input file] [-legend file] [-slope right,down]...")
+	fmt.Fprintln(os.Stderr, "       aoc passports [-input file] [-schema file] [-lenient] [-details] [-workers n] [-print-schema] [-generate n [-seed n]]")
+	fmt.Fprintln(os.Stderr, "       aoc serve [-addr host:port] [-schema file]")
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"aoc2020/day04"
+	"aoc2020/utils/fileinput"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// passports checks a day 4 style batch of passports against a schema, which can
+// be changed without rebuilding.
+func passports(args []string) error {
+	fs := flag.NewFlagSet("passports", flag.ExitOnError)
+	inputPath := fs.String("input", "", "passport batch, or - for stdin (defaults to day 4's embedded input)")
+	schemaPath := fs.String("schema", "", "JSON schema for the fields (defaults to the puzzle's rules)")
+	printSchema := fs.Bool("print-schema", false, "print the default schema, as a starting point for other rules, and exit")
+	details := fs.Bool("details", false, "list every problem with each invalid passport")
+	lenient := fs.Bool("lenient", false, "warn about malformed, duplicate and unknown fields rather than stopping at the first")
+	workers := fs.Int("workers", 0, "validate across this many goroutines as the batch is read, for batches too large to hold in memory (0 reads the whole batch first)")
+	generate := fs.Int("generate", 0, "write this many made-up passports to stdout, for trying out -workers, and exit")
+	seed := fs.Int64("seed", 1, "seed for -generate")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("passports: unexpected arguments")
+	}
+
+	if *printSchema {
+		fmt.Print(day04.DefaultSchema)
+		return nil
+	}
+
+	if *generate > 0 {
+		return day04.GenerateBatch(os.Stdout, *generate, *seed)
+	}
+
+	if *workers > 0 && *details {
+		return errors.New("passports: -details can't be used with -workers")
+	}
+
+	v, err := loadSchema(*schemaPath)
+	if err != nil {
+		return err
+	}
+
+	r, err := day04.Solver.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if *workers > 0 {
+		start := time.Now()
+		report, warnings, err := v.ValidateStream(r, *workers, !*lenient)
+		printWarnings(warnings)
+		if err != nil {
+			return err
+		}
+
+		elapsed := time.Since(start)
+		fmt.Fprintf(os.Stderr, "validated %v passports in %v (%.0f/s)\n", report.Total, elapsed.Round(time.Millisecond), float64(report.Total)/elapsed.Seconds())
+
+		printPassportReport(report)
+		return nil
+	}
+
+	credentials, warnings, err := day04.ParseBatch(r, v, !*lenient)
+	printWarnings(warnings)
+	if err != nil {
+		return err
+	}
+
+	results, report := v.ValidateAll(credentials)
+
+	if *details {
+		for i, result := range results {
+			if !result.Valid() {
+				fmt.Printf("passport %v: %v\n", i+1, result)
+			}
+		}
+	}
+
+	printPassportReport(report)
+	return nil
+}
+
+func printWarnings(warnings []*fileinput.ParseError) {
+	// Warnings go to stderr, as for passwords
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", w)
+	}
+	if len(warnings) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: %v problems in the batch\n", len(warnings))
+	}
+}
+
+func printPassportReport(report day04.BatchReport) {
+	fmt.Printf("%v of %v passports have every required field\n", report.Complete, report.Total)
+	fmt.Printf("%v of %v passports are valid\n", report.Valid, report.Total)
+	fmt.Printf("Problems: %v\n", report)
+}
+
+// loadSchema compiles the schema at path, or the default schema if path is
+// empty.
+func loadSchema(path string) (*day04.Validator, error) {
+	if path == "" {
+		return day04.DefaultValidator(), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	v, err := day04.LoadSchema(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	return v, nil
+}
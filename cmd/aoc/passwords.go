@@ -0,0 +1,262 @@
+package main
+
+import (
+	"aoc2020/day02"
+	"aoc2020/utils/fileinput"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// policyFlags collects each -policy given, in order.
+type policyFlags []string
+
+func (p *policyFlags) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *policyFlags) Set(spec string) error {
+	*p = append(*p, spec)
+	return nil
+}
+
+// passwords counts the valid passwords in a day 2 style password database
+// under each of the chosen policies, so one database can be checked against
+// different rules.
+func passwords(args []string) error {
+	fs := flag.NewFlagSet("passwords", flag.ExitOnError)
+	inputPath := fs.String("input", "", "password database, or - for stdin (defaults to day 2's embedded input)")
+	var specs policyFlags
+	fs.Var(&specs, "policy", fmt.Sprintf("policy to check, one of %v, with an argument after a colon if it takes one; may be repeated", strings.Join(day02.PolicyNames(), ", ")))
+	report := fs.Bool("report", false, "list every entry with its verdict under each policy, and why it failed")
+	format := fs.String("format", "text", "report format: text, json or csv")
+	lenient := fs.Bool("lenient", false, "skip malformed lines, listing them as warnings, rather than stopping at the first")
+	warningsPath := fs.String("warnings", "", "with -lenient, also write the skipped lines as CSV to this file, alongside a CSV report")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("passwords: unexpected arguments")
+	}
+
+	if len(specs) == 0 {
+		specs = policyFlags{"count-range", "exactly-one-position"}
+	}
+
+	if *format != "text" && !*report {
+		return errors.New("passwords: -format needs -report")
+	}
+
+	if *warningsPath != "" && !*lenient {
+		return errors.New("passwords: -warnings needs -lenient")
+	}
+
+	// A report builds its own policies from the specs
+	var policies []day02.Policy
+	if !*report {
+		for _, spec := range specs {
+			p, err := day02.NewPolicy(spec)
+			if err != nil {
+				return err
+			}
+			policies = append(policies, p)
+		}
+	}
+
+	r, err := day02.Solver.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var entries []day02.Entry
+	var warnings []*fileinput.ParseError
+	if *lenient {
+		entries, warnings, err = day02.ParseLenient(r)
+
+		// Warnings go to stderr so that a report on stdout stays machine-readable
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", w)
+		}
+		if len(warnings) > 0 {
+			fmt.Fprintf(os.Stderr, "warning: skipped %v malformed lines\n", len(warnings))
+		}
+	} else {
+		var input interface{}
+		input, err = day02.Solver.Parse(r)
+		if err == nil {
+			entries = input.([]day02.Entry)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if *report {
+		reports, err := day02.Report(entries, specs...)
+		if err != nil {
+			return err
+		}
+
+		if *warningsPath != "" {
+			if err := writeWarningsFile(*warningsPath, warnings); err != nil {
+				return err
+			}
+		}
+
+		return writeReport(os.Stdout, *format, reports, warnings)
+	}
+
+	if *warningsPath != "" {
+		return writeWarningsFile(*warningsPath, warnings)
+	}
+
+	for i, p := range policies {
+		fmt.Printf("%v: %v of %v passwords valid\n", specs[i], day02.CountValid(entries, p), len(entries))
+	}
+
+	return nil
+}
+
+// verdictRecord is a day02.Verdict flattened for machine-readable output.
+type verdictRecord struct {
+	Policy string `json:"policy"`
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason"`
+}
+
+// entryRecord is a day02.EntryReport flattened for machine-readable output.
+type entryRecord struct {
+	Entry    int             `json:"entry"`
+	Char     string          `json:"char"`
+	Low      int             `json:"low"`
+	High     int             `json:"high"`
+	Password string          `json:"password"`
+	Verdicts []verdictRecord `json:"verdicts"`
+}
+
+// warningRecord is a line skipped by -lenient, for machine-readable output.
+// Line is its line in the database.
+type warningRecord struct {
+	Line   int    `json:"line"`
+	Text   string `json:"text"`
+	Reason string `json:"reason"`
+}
+
+// reportRecord is the JSON report: every entry's verdicts, and the lines that
+// were skipped to get them.
+type reportRecord struct {
+	Entries  []entryRecord   `json:"entries"`
+	Warnings []warningRecord `json:"warnings"`
+}
+
+func warningRecords(warnings []*fileinput.ParseError) []warningRecord {
+	records := []warningRecord{}
+	for _, w := range warnings {
+		records = append(records, warningRecord{w.Line, w.Text, w.Err.Error()})
+	}
+	return records
+}
+
+// writeReport writes a password report in one of the formats accepted by
+// -format. CSV has a row for each entry under each policy. JSON includes the
+// lines skipped by -lenient; for text they're only on stderr, and for CSV
+// they're written separately by writeWarnings.
+func writeReport(w io.Writer, format string, reports []day02.EntryReport, warnings []*fileinput.ParseError) error {
+	switch format {
+	case "text":
+		for _, r := range reports {
+			if _, err := fmt.Fprintf(w, "%v: %v\n", r.Number, r.Entry); err != nil {
+				return err
+			}
+
+			for _, v := range r.Verdicts {
+				result := "PASS"
+				if !v.Valid {
+					result = "FAIL: " + v.Reason
+				}
+
+				if _, err := fmt.Fprintf(w, "  %v: %v\n", v.Policy, result); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case "json":
+		report := reportRecord{Entries: []entryRecord{}, Warnings: warningRecords(warnings)}
+		for _, r := range reports {
+			rec := entryRecord{
+				Entry:    r.Number,
+				Char:     r.Entry.Char,
+				Low:      r.Entry.Low,
+				High:     r.Entry.High,
+				Password: r.Entry.Password,
+				Verdicts: []verdictRecord{},
+			}
+			for _, v := range r.Verdicts {
+				rec.Verdicts = append(rec.Verdicts, verdictRecord{v.Policy, v.Valid, v.Reason})
+			}
+			report.Entries = append(report.Entries, rec)
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"entry", "char", "low", "high", "password", "policy", "valid", "reason"})
+
+		for _, r := range reports {
+			for _, v := range r.Verdicts {
+				cw.Write([]string{
+					strconv.Itoa(r.Number),
+					r.Entry.Char,
+					strconv.Itoa(r.Entry.Low),
+					strconv.Itoa(r.Entry.High),
+					r.Entry.Password,
+					v.Policy,
+					strconv.FormatBool(v.Valid),
+					v.Reason,
+				})
+			}
+		}
+
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown format %q (want text, json or csv)", format)
+	}
+}
+
+// writeWarnings writes the lines skipped by -lenient as CSV, to go with a CSV
+// report.
+func writeWarnings(w io.Writer, warnings []*fileinput.ParseError) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"line", "text", "reason"})
+
+	for _, rec := range warningRecords(warnings) {
+		cw.Write([]string{strconv.Itoa(rec.Line), rec.Text, rec.Reason})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeWarningsFile(path string, warnings []*fileinput.ParseError) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := writeWarnings(f, warnings); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
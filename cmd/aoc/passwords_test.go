@@ -0,0 +1,99 @@
+package main
+
+import (
+	"aoc2020/day02"
+	"aoc2020/utils/fileinput"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func Test_writeReport(t *testing.T) {
+	reports := []day02.EntryReport{
+		{Number: 1, Entry: day02.Entry{Char: "m", Low: 8, High: 11, Password: "wzxcmwgmmvmgq"}, Verdicts: []day02.Verdict{
+			{Policy: "count-range", Reason: `'m' appears 4 times, need 8-11`},
+			{Policy: "none-of-positions", Valid: true},
+		}},
+	}
+
+	warnings := []*fileinput.ParseError{
+		{Line: 2, Record: 2, Text: "bad line", Err: errors.New("malformed entry")},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "text",
+			want: "1: 8-11 m: wzxcmwgmmvmgq\n" +
+				"  count-range: FAIL: 'm' appears 4 times, need 8-11\n" +
+				"  none-of-positions: PASS\n",
+		},
+		{
+			format: "csv",
+			want: "entry,char,low,high,password,policy,valid,reason\n" +
+				"1,m,8,11,wzxcmwgmmvmgq,count-range,false,\"'m' appears 4 times, need 8-11\"\n" +
+				"1,m,8,11,wzxcmwgmmvmgq,none-of-positions,true,\n",
+		},
+		{
+			format: "json",
+			want: `{
+  "entries": [
+    {
+      "entry": 1,
+      "char": "m",
+      "low": 8,
+      "high": 11,
+      "password": "wzxcmwgmmvmgq",
+      "verdicts": [
+        {
+          "policy": "count-range",
+          "valid": false,
+          "reason": "'m' appears 4 times, need 8-11"
+        },
+        {
+          "policy": "none-of-positions",
+          "valid": true,
+          "reason": ""
+        }
+      ]
+    }
+  ],
+  "warnings": [
+    {
+      "line": 2,
+      "text": "bad line",
+      "reason": "malformed entry"
+    }
+  ]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := writeReport(&buf, tt.format, reports, warnings); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeReport() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := writeReport(&bytes.Buffer{}, "xml", reports, nil); err == nil {
+		t.Error("writeReport() error = nil, want an unknown format error")
+	}
+
+	var buf bytes.Buffer
+	if err := writeWarnings(&buf, warnings); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "line,text,reason\n2,bad line,malformed entry\n"; got != want {
+		t.Errorf("writeWarnings() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"aoc2020/day03"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// slopeFlags collects each -slope given, in order.
+type slopeFlags []day03.Slope
+
+func (s *slopeFlags) String() string {
+	var slopes []string
+	for _, slope := range *s {
+		slopes = append(slopes, fmt.Sprintf("%v,%v", slope.Right, slope.Down))
+	}
+	return strings.Join(slopes, " ")
+}
+
+// Set parses a slope given as "right,down", the order the puzzle uses.
+func (s *slopeFlags) Set(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid slope %q, want right,down", value)
+	}
+
+	right, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid slope %q, want right,down", value)
+	}
+
+	down, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return fmt.Errorf("invalid slope %q, want right,down", value)
+	}
+
+	*s = append(*s, day03.Slope{Down: down, Right: right})
+	return nil
+}
+
+// render draws the path taken down a day 3 map at one or more slopes.
+func render(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	inputPath := fs.String("input", "", "map, or - for stdin (defaults to day 3's embedded input)")
+	var slopes slopeFlags
+	fs.Var(&slopes, "slope", "slope as right,down, such as 3,1; may be repeated for svg")
+	format := fs.String("format", "ascii", "output format: ascii or svg")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("render: unexpected arguments")
+	}
+
+	if len(slopes) == 0 {
+		slopes = slopeFlags{{Down: 1, Right: 3}}
+	}
+
+	r, err := day03.Solver.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	input, err := day03.Solver.Parse(r)
+	if err != nil {
+		return err
+	}
+
+	rows := input.([]day03.Row)
+
+	switch *format {
+	case "ascii":
+		if len(slopes) != 1 {
+			return errors.New("render: ascii shows one slope at a time, use -format svg for more")
+		}
+		return day03.RenderASCII(os.Stdout, rows, slopes[0])
+	case "svg":
+		return day03.RenderSVG(os.Stdout, rows, slopes)
+	default:
+		return fmt.Errorf("unknown format %q (want ascii or svg)", *format)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"aoc2020/solver"
+	"errors"
+	"flag"
+	"os"
+)
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input file, or - for stdin (defaults to the embedded input)")
+	format := fs.String("format", "text", "output format: text, json or csv")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("run: expected a single day, range of days, or \"all\"")
+	}
+
+	out, err := newResultWriter(*format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	solvers, err := registry.Select(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *inputPath != "" && len(solvers) > 1 {
+		return errors.New("run: -input can only be used with a single day")
+	}
+
+	failed := false
+	for _, s := range solvers {
+		for _, result := range runSolver(s, *inputPath) {
+			if result.Err != nil {
+				failed = true
+			}
+
+			if err := out.Write(result); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("one or more days failed")
+	}
+
+	return nil
+}
+
+// runSolver solves both parts of a day, reporting a failure to open the input
+// against each part so every day always produces two results.
+func runSolver(s solver.Solver, inputPath string) []solver.Result {
+	r, err := s.Open(inputPath)
+	if err != nil {
+		return []solver.Result{
+			{Day: s.Day, Part: 1, Err: err},
+			{Day: s.Day, Part: 2, Err: err},
+		}
+	}
+	defer r.Close()
+
+	results, _ := s.Solve(r)
+	return results
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"aoc2020/day04"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+// serve runs the day 4 passport validator as an HTTP service, taking batches
+// POSTed to /validate.
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8004", "address to listen on")
+	schemaPath := fs.String("schema", "", "JSON schema for the fields (defaults to the puzzle's rules)")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("serve: unexpected arguments")
+	}
+
+	v, err := loadSchema(*schemaPath)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/validate", day04.NewHandler(v))
+
+	srv := &http.Server{
+		Addr:         *addr,
+		Handler:      mux,
+		ReadTimeout:  time.Minute,
+		WriteTimeout: time.Minute,
+	}
+
+	log.Printf("validating passports at http://%v/validate", *addr)
+
+	return srv.ListenAndServe()
+}
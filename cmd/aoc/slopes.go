@@ -0,0 +1,73 @@
+package main
+
+import (
+	"aoc2020/day03"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// slopes searches for the slopes down a day 3 map that hit the fewest, or the
+// most, trees.
+func slopes(args []string) error {
+	fs := flag.NewFlagSet("slopes", flag.ExitOnError)
+	inputPath := fs.String("input", "", "map, or - for stdin (defaults to day 3's embedded input)")
+	minDown := fs.Int("min-down", 1, "fewest rows down per step")
+	maxDown := fs.Int("max-down", 2, "most rows down per step")
+	minRight := fs.Int("min-right", 0, "fewest columns right per step, negative to head left")
+	maxRight := fs.Int("max-right", 7, "most columns right per step")
+	most := fs.Bool("most", false, "look for the most trees rather than the fewest")
+	all := fs.Bool("all", false, "show the full ranking, not just the best slopes")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("slopes: unexpected arguments")
+	}
+
+	r, err := day03.Solver.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	input, err := day03.Solver.Parse(r)
+	if err != nil {
+		return err
+	}
+
+	goal := day03.FewestTrees
+	if *most {
+		goal = day03.MostTrees
+	}
+
+	bounds := day03.SlopeBounds{MinDown: *minDown, MaxDown: *maxDown, MinRight: *minRight, MaxRight: *maxRight}
+
+	search, err := day03.SearchSlopes(input.([]day03.Row), bounds, goal)
+	if err != nil {
+		return err
+	}
+
+	shown := search.Best
+	if *all {
+		shown = search.Ranking
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "Rank\tDown\tRight\tTrees\t")
+
+	rank := 0
+	for i, s := range shown {
+		// Slopes hitting the same number of trees share a rank
+		if i == 0 || s.Trees != shown[i-1].Trees {
+			rank = i + 1
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", rank, s.Down, s.Right, s.Trees)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"aoc2020/day03"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// traverse breaks down the cells visited going down a day 3 style map by
+// terrain, with what each terrain cost.
+func traverse(args []string) error {
+	fs := flag.NewFlagSet("traverse", flag.ExitOnError)
+	inputPath := fs.String("input", "", "map, or - for stdin (defaults to day 3's embedded input)")
+	legendPath := fs.String("legend", "", `legend file with a "char name cost" line per terrain (defaults to open and tree)`)
+	var slopes slopeFlags
+	fs.Var(&slopes, "slope", "slope as right,down, such as 3,1; may be repeated")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("traverse: unexpected arguments")
+	}
+
+	if len(slopes) == 0 {
+		slopes = slopeFlags{{Down: 1, Right: 3}}
+	}
+
+	legend := day03.DefaultLegend
+	if *legendPath != "" {
+		f, err := os.Open(*legendPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if legend, err = day03.ParseLegend(f); err != nil {
+			return err
+		}
+	}
+
+	r, err := day03.Solver.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	rows, err := day03.ParseMap(r, legend)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "Right\tDown\tTerrain\tCount\tCost\t")
+
+	for _, s := range slopes {
+		t, err := day03.Traverse(rows, s, legend)
+		if err != nil {
+			return err
+		}
+
+		for _, tc := range t.ByTerrain {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", s.Right, s.Down, tc.Name, tc.Count, tc.TotalCost)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t\t%v\t\n", s.Right, s.Down, "total", t.TotalCost)
+	}
+
+	return nil
+}
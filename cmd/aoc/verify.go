@@ -0,0 +1,132 @@
+package main
+
+import (
+	"aoc2020/solver"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func verify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input file, or - for stdin (defaults to the embedded input)")
+	answersPath := fs.String("answers", "", "answers manifest for the input (defaults to the embedded answers)")
+	fs.Parse(args)
+
+	spec := "all"
+	if fs.NArg() == 1 {
+		spec = fs.Arg(0)
+	} else if fs.NArg() > 1 {
+		return errors.New("verify: expected a single day, range of days, or \"all\"")
+	}
+
+	solvers, err := registry.Select(spec)
+	if err != nil {
+		return err
+	}
+
+	if *inputPath != "" {
+		if len(solvers) > 1 {
+			return errors.New("verify: -input can only be used with a single day")
+		}
+
+		if *answersPath == "" {
+			return errors.New("verify: -input requires an -answers manifest")
+		}
+	}
+
+	var override solver.Answers
+	if *answersPath != "" {
+		override, err = loadAnswers(*answersPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	passed, failed := 0, 0
+	for _, s := range solvers {
+		expected, err := solver.ParseAnswers(strings.NewReader(s.Answers))
+		if err != nil {
+			return fmt.Errorf("day %v answers: %v", s.Day, err)
+		}
+
+		if override != nil {
+			expected = override
+		}
+
+		p, f := verifySolver(s, *inputPath, expected, override != nil)
+		passed += p
+		failed += f
+	}
+
+	fmt.Printf("%v passed, %v failed\n", passed, failed)
+
+	if failed > 0 {
+		return errors.New("verification failed")
+	}
+
+	// A manifest that matches nothing mustn't pass by checking nothing
+	if passed == 0 {
+		return errors.New("verification failed: no parts were checked")
+	}
+
+	return nil
+}
+
+// verifySolver solves the day and checks each part against the expected
+// answers. A part missing from them is skipped, unless strict is set, as it is
+// for a manifest given with -answers, where it's more likely a mistake.
+func verifySolver(s solver.Solver, inputPath string, expected solver.Answers, strict bool) (passed, failed int) {
+	r, err := s.Open(inputPath)
+	if err != nil {
+		fmt.Printf("Day %v: ERROR %v\n", s.Day, err)
+		return 0, 1
+	}
+	defer r.Close()
+
+	results, err := s.Solve(r)
+	if err != nil {
+		fmt.Printf("Day %v: ERROR %v\n", s.Day, err)
+		return 0, 1
+	}
+
+	for _, result := range results {
+		want, ok := expected[solver.PartKey{Day: result.Day, Part: result.Part}]
+
+		switch {
+		case result.Err != nil:
+			fmt.Printf("Day %v part %v: ERROR %v\n", result.Day, result.Part, result.Err)
+			failed++
+		case !ok && strict:
+			fmt.Printf("Day %v part %v: FAIL no expected answer in the manifest (got %v)\n", result.Day, result.Part, result.Answer)
+			failed++
+		case !ok:
+			fmt.Printf("Day %v part %v: SKIP no expected answer (got %v)\n", result.Day, result.Part, result.Answer)
+		case fmt.Sprint(result.Answer) != want:
+			fmt.Printf("Day %v part %v: FAIL got %v, want %v\n", result.Day, result.Part, result.Answer, want)
+			failed++
+		default:
+			fmt.Printf("Day %v part %v: PASS %v\n", result.Day, result.Part, result.Answer)
+			passed++
+		}
+	}
+
+	return passed, failed
+}
+
+func loadAnswers(path string) (solver.Answers, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	answers, err := solver.ParseAnswers(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	return answers, nil
+}
@@ -0,0 +1,190 @@
+// Command genexamples generates table-driven tests from the worked examples in
+// a day's puzzle description.
+//
+// The examples are transcribed from the puzzle text at the top of each day's
+// source into an examples.txt file beside it. Anything before the first
+// directive is free-form notes. After that, each example starts with
+//
+//	=== example <name>
+//
+// followed by its input, verbatim, then the answers the puzzle gives for it:
+//
+//	=== part1 <answer>
+//	=== part2 <answer>
+//
+// A part with no answer in the puzzle text can be left out. An example that
+// needs package state adjusting first can name a func(*testing.T) in the
+// day's tests to call before it is solved:
+//
+//	=== setup <func>
+//
+// It is run via go:generate from the day's directory, and writes
+// examples_test.go there.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type example struct {
+	name  string
+	input string
+	part1 string
+	part2 string
+	setup string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("genexamples: ")
+
+	pkg := os.Getenv("GOPACKAGE")
+	if pkg == "" {
+		log.Fatal("GOPACKAGE not set; run via go generate")
+	}
+
+	f, err := os.Open("examples.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	examples, err := parseExamples(f)
+	if err != nil {
+		log.Fatalf("examples.txt: %v", err)
+	}
+
+	src, err := generate(pkg, examples)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile("examples_test.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseExamples(r io.Reader) ([]example, error) {
+	var examples []example
+	var ex *example
+	var inputLines []string
+	inInput := false
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		if !strings.HasPrefix(line, "=== ") {
+			if inInput {
+				inputLines = append(inputLines, line)
+			}
+			continue
+		}
+
+		fields := strings.SplitN(strings.TrimPrefix(line, "=== "), " ", 2)
+		directive, arg := fields[0], ""
+		if len(fields) == 2 {
+			arg = strings.TrimSpace(fields[1])
+		}
+
+		if directive == "example" {
+			examples = append(examples, example{name: arg})
+			ex = &examples[len(examples)-1]
+			inputLines = nil
+			inInput = true
+
+			if ex.name == "" {
+				ex.name = fmt.Sprintf("example %v", len(examples))
+			}
+			continue
+		}
+
+		if ex == nil {
+			return nil, fmt.Errorf("line %v: %q before any example", lineNum, line)
+		}
+
+		if inInput {
+			ex.input = strings.TrimRight(strings.Join(inputLines, "\n"), "\n") + "\n"
+			inInput = false
+		}
+
+		if arg == "" {
+			return nil, fmt.Errorf("line %v: %v needs a value", lineNum, directive)
+		}
+
+		switch directive {
+		case "part1":
+			ex.part1 = arg
+		case "part2":
+			ex.part2 = arg
+		case "setup":
+			ex.setup = arg
+		default:
+			return nil, fmt.Errorf("line %v: unknown directive %q", lineNum, directive)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, ex := range examples {
+		if ex.part1 == "" && ex.part2 == "" {
+			return nil, fmt.Errorf("%v has no answers", ex.name)
+		}
+	}
+
+	return examples, nil
+}
+
+func generate(pkg string, examples []example) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by genexamples from examples.txt. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %v\n\n", pkg)
+	fmt.Fprintf(&buf, "import (\n\"aoc2020/solver/solvertest\"\n\"testing\"\n)\n\n")
+	fmt.Fprintf(&buf, "func TestExamples(t *testing.T) {\n")
+	fmt.Fprintf(&buf, "solvertest.RunExamples(t, Solver, []solvertest.Example{\n")
+
+	for _, ex := range examples {
+		fmt.Fprintf(&buf, "{\n")
+		fmt.Fprintf(&buf, "Name: %v,\n", strconv.Quote(ex.name))
+		fmt.Fprintf(&buf, "Input: %v,\n", quote(ex.input))
+		if ex.part1 != "" {
+			fmt.Fprintf(&buf, "Part1: %v,\n", strconv.Quote(ex.part1))
+		}
+		if ex.part2 != "" {
+			fmt.Fprintf(&buf, "Part2: %v,\n", strconv.Quote(ex.part2))
+		}
+		if ex.setup != "" {
+			fmt.Fprintf(&buf, "Setup: %v,\n", ex.setup)
+		}
+		fmt.Fprintf(&buf, "},\n")
+	}
+
+	fmt.Fprintf(&buf, "})\n}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// quote returns s as a raw string literal where possible, so multi-line inputs
+// read the same in the generated tests as they do in the puzzle.
+func quote(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseExamples(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []example
+		wantErr bool
+	}{
+		{
+			name:  "inputs and answers",
+			input: "Notes about the examples\n\n=== example\n1721\n979\n\n=== part1 514579\n=== part2 241861950\n=== example larger\n#.#\n\n.#.\n=== part2 8\n=== setup useExample\n",
+			want: []example{
+				{name: "example 1", input: "1721\n979\n", part1: "514579", part2: "241861950"},
+				{name: "larger", input: "#.#\n\n.#.\n", part2: "8", setup: "useExample"},
+			},
+		},
+		{name: "directive before example", input: "=== part1 4\n", wantErr: true},
+		{name: "unknown directive", input: "=== example\n1\n=== part3 4\n", wantErr: true},
+		{name: "no answers", input: "=== example\n1\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExamples(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseExamples() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseExamples() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
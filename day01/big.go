@@ -0,0 +1,115 @@
+package day01
+
+import (
+	"aoc2020/solver"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// BigMatch is a Match over entries of any size, for reports whose entries,
+// sums or products don't fit in an int.
+type BigMatch struct {
+	Indices []int
+	Entries []*big.Int
+}
+
+// Product multiplies the match's entries together.
+func (m BigMatch) Product() *big.Int {
+	p := big.NewInt(1)
+	for _, e := range m.Entries {
+		p.Mul(p, e)
+	}
+	return p
+}
+
+// bigEntry is an expense report entry of any size along with its position in
+// the report.
+type bigEntry struct {
+	value *big.Int
+	index int
+}
+
+// KSumBig is KSum for entries of any size, doing its sums with math/big so
+// they can't overflow.
+func KSumBig(nums []*big.Int, k int, target *big.Int) (BigMatch, error) {
+	if k < 1 {
+		return BigMatch{}, fmt.Errorf("k must be at least 1, got %v", k)
+	}
+
+	entries := make([]bigEntry, len(nums))
+	for i, num := range nums {
+		entries[i] = bigEntry{num, i}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if c := entries[i].value.Cmp(entries[j].value); c != 0 {
+			return c < 0
+		}
+		return entries[i].index < entries[j].index
+	})
+
+	found, ok := kSumBig(entries, k, target)
+	if !ok {
+		return BigMatch{}, fmt.Errorf("%w: no %v entries sum to %v", solver.ErrNoSolution, k, target)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].index < found[j].index
+	})
+
+	m := BigMatch{}
+	for _, e := range found {
+		m.Indices = append(m.Indices, e.index)
+		m.Entries = append(m.Entries, e.value)
+	}
+
+	return m, nil
+}
+
+func kSumBig(sorted []bigEntry, k int, target *big.Int) ([]bigEntry, bool) {
+	if len(sorted) < k {
+		return nil, false
+	}
+
+	switch k {
+	case 1:
+		i := sort.Search(len(sorted), func(i int) bool {
+			return sorted[i].value.Cmp(target) >= 0
+		})
+		if i < len(sorted) && sorted[i].value.Cmp(target) == 0 {
+			return []bigEntry{sorted[i]}, true
+		}
+		return nil, false
+	case 2:
+		sum := new(big.Int)
+		for lo, hi := 0, len(sorted)-1; lo < hi; {
+			sum.Add(sorted[lo].value, sorted[hi].value)
+
+			switch sum.Cmp(target) {
+			case 0:
+				return []bigEntry{sorted[lo], sorted[hi]}, true
+			case -1:
+				lo++
+			default:
+				hi--
+			}
+		}
+		return nil, false
+	}
+
+	remaining := new(big.Int)
+	for i := 0; i <= len(sorted)-k; i++ {
+		// An entry with the same value as the last would only find the same matches
+		if i > 0 && sorted[i].value.Cmp(sorted[i-1].value) == 0 {
+			continue
+		}
+
+		remaining.Sub(target, sorted[i].value)
+		if rest, ok := kSumBig(sorted[i+1:], k-1, remaining); ok {
+			return append([]bigEntry{sorted[i]}, rest...), true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,378 @@
+// --- Day 1: Report Repair ---
+//
+// After saving Christmas five years in a row, you've decided to take a vacation at a nice
+// resort on a tropical island. Surely, Christmas will go on without you.
+//
+// The tropical island has its own currency and is entirely cash-only. The gold coins used
+// there have a little picture of a starfish; the locals just call them stars. None of the
+// currency exchanges seem to have heard of them, but somehow, you'll need to find fifty
+// of these coins by the time you arrive so you can pay the deposit on your room.
+//
+// To save your vacation, you need to get all fifty stars by December 25th.
+//
+// Collect stars by solving puzzles. Two puzzles will be made available on each day in the
+// Advent calendar; the second puzzle is unlocked when you complete the first. Each puzzle
+// grants one star. Good luck!
+//
+// Before you leave, the Elves in accounting just need you to fix your expense report
+// (your puzzle input); apparently, something isn't quite adding up.
+//
+// Specifically, they need you to find the two entries that sum to 2020 and then multiply
+// those two numbers together.
+//
+// For example, suppose your expense report contained the following:
+//
+// 1721
+// 979
+// 366
+// 299
+// 675
+// 1456
+//
+// In this list, the two entries that sum to 2020 are 1721 and 299. Multiplying them
+// together produces 1721 * 299 = 514579, so the correct answer is 514579.
+//
+// Of course, your expense report is much larger. Find the two entries that sum to 2020;
+// what do you get if you multiply them together?
+//
+// --- Part Two ---
+//
+// The Elves in accounting are thankful for your help; one of them even offers you a
+// starfish coin they had left over from a past vacation. They offer you a second one if
+// you can find three numbers in your expense report that meet the same criteria.
+//
+// Using the above example again, the three entries that sum to 2020 are 979, 366, and
+// 675. Multiplying them together produces the answer, 241861950.
+//
+// In your expense report, what is the product of the three entries that sum to 2020?
+
+package day01
+
+import (
+	"aoc2020/solver"
+	"aoc2020/utils/fileinput"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+var magicNumber = 2020
+
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     1,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return fileinput.ReadInts(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]int))
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.([]int))
+	},
+}
+
+func part1(nums []int) (int, error) {
+	// Search for the two numbers adding to 2020
+	match, err := KSum(nums, 2, magicNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	return match.Product()
+}
+
+func part2(nums []int) (int, error) {
+	// Search for THREE numbers adding to 2020
+	match, err := KSum(nums, 3, magicNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	return match.Product()
+}
+
+// Match is a set of entries adding up to the target. Indices are the entries'
+// 0-based positions in the expense report, in ascending order, and Entries
+// holds the entry at each of those positions.
+type Match struct {
+	Indices []int
+	Entries []int
+}
+
+// ErrOverflow is returned when a sum or product doesn't fit in an int.
+// KSumBig and BigMatch work with entries of any size.
+var ErrOverflow = errors.New("integer overflow")
+
+// Product multiplies the match's entries together.
+func (m Match) Product() (int, error) {
+	p := 1
+	for _, e := range m.Entries {
+		var ok bool
+		if p, ok = mulInt(p, e); !ok {
+			return 0, fmt.Errorf("product of %v: %w", m.Entries, ErrOverflow)
+		}
+	}
+	return p, nil
+}
+
+// entry is an expense report entry along with its position in the report.
+type entry struct {
+	value int
+	index int
+}
+
+// KSum finds k entries, each from a different position in nums, that add up
+// to target.
+//
+// The entries are sorted first, so pairs are found with a single two-pointer
+// sweep, and each extra addend costs one more pass over the entries: O(n log n)
+// for k = 2, O(n²) for k = 3, and O(n^(k-1)) in general.
+//
+// Sums too large for an int are never wrapped round into a match. If no match
+// is found and part of the search couldn't be done for that reason, the error
+// wraps ErrOverflow.
+func KSum(nums []int, k int, target int) (Match, error) {
+	if k < 1 {
+		return Match{}, fmt.Errorf("k must be at least 1, got %v", k)
+	}
+
+	found, ok, err := kSum(sortedEntries(nums), k, target)
+	if err != nil {
+		return Match{}, err
+	}
+	if !ok {
+		return Match{}, fmt.Errorf("%w: no %v entries sum to %v", solver.ErrNoSolution, k, target)
+	}
+
+	return newMatch(found), nil
+}
+
+func kSum(sorted []entry, k int, target int) ([]entry, bool, error) {
+	if len(sorted) < k {
+		return nil, false, nil
+	}
+
+	switch k {
+	case 1:
+		i := searchEntries(sorted, target)
+		if i < len(sorted) && sorted[i].value == target {
+			return []entry{sorted[i]}, true, nil
+		}
+		return nil, false, nil
+	case 2:
+		for lo, hi := 0, len(sorted)-1; lo < hi; {
+			sum, ok := addInt(sorted[lo].value, sorted[hi].value)
+
+			switch {
+			case !ok:
+				// An overflowing pair is too far from any target an int can
+				// hold, in the direction of the overflow
+				if sorted[lo].value < 0 {
+					lo++
+				} else {
+					hi--
+				}
+			case sum == target:
+				return []entry{sorted[lo], sorted[hi]}, true, nil
+			case sum < target:
+				lo++
+			default:
+				hi--
+			}
+		}
+		return nil, false, nil
+	}
+
+	// An overflow only means this search can't be finished, so it's reported if
+	// no match turns up elsewhere
+	var overflow error
+
+	for i := 0; i <= len(sorted)-k; i++ {
+		// An entry with the same value as the last would only find the same matches
+		if i > 0 && sorted[i].value == sorted[i-1].value {
+			continue
+		}
+
+		remaining, ok := subInt(target, sorted[i].value)
+		if !ok {
+			overflow = remainderOverflow(overflow, k, target, sorted[i].value)
+			continue
+		}
+
+		rest, ok, err := kSum(sorted[i+1:], k-1, remaining)
+		if ok {
+			return append([]entry{sorted[i]}, rest...), true, nil
+		}
+		if err != nil && overflow == nil {
+			overflow = err
+		}
+	}
+
+	return nil, false, overflow
+}
+
+// remainderOverflow records that target - value overflowed while looking for k
+// entries, keeping the first overflow recorded. When only one entry would be
+// left to find, no int could equal the remainder, so that's no overflow at all.
+func remainderOverflow(overflow error, k, target, value int) error {
+	if overflow != nil || k-1 == 1 {
+		return overflow
+	}
+	return fmt.Errorf("%v - %v: %w", target, value, ErrOverflow)
+}
+
+// KSumAll finds every combination of k entries that add up to target, ordered
+// by their indices. Each combination of positions is reported once, so equal
+// entries at different positions make separate matches. With allowReuse, an
+// entry may be used more than once in the same match.
+//
+// Like KSum, the final addend is found by searching the sorted entries, so this
+// takes O(n^(k-1) log n) time plus the time to build the matches. As with
+// KSum, combinations needing a sum too large for an int are passed over, and
+// ErrOverflow is only returned if no match is found.
+func KSumAll(nums []int, k int, target int, allowReuse bool) ([]Match, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("k must be at least 1, got %v", k)
+	}
+
+	var matches []Match
+
+	overflow := kSumAll(sortedEntries(nums), k, target, allowReuse, nil, func(found []entry) {
+		matches = append(matches, newMatch(found))
+	})
+
+	if len(matches) == 0 {
+		if overflow != nil {
+			return nil, overflow
+		}
+		return nil, fmt.Errorf("%w: no %v entries sum to %v", solver.ErrNoSolution, k, target)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i].Indices, matches[j].Indices
+		for n := range a {
+			if a[n] != b[n] {
+				return a[n] < b[n]
+			}
+		}
+		return false
+	})
+
+	return matches, nil
+}
+
+// kSumAll passes each match to found, returning an error wrapping ErrOverflow
+// if any of the search had to be passed over.
+func kSumAll(sorted []entry, k int, target int, allowReuse bool, chosen []entry, found func([]entry)) error {
+	if k == 1 {
+		for i := searchEntries(sorted, target); i < len(sorted) && sorted[i].value == target; i++ {
+			found(append(chosen[:len(chosen):len(chosen)], sorted[i]))
+		}
+		return nil
+	}
+
+	var overflow error
+
+	for i := range sorted {
+		rest := sorted[i+1:]
+		if allowReuse {
+			rest = sorted[i:]
+		}
+
+		remaining, ok := subInt(target, sorted[i].value)
+		if !ok {
+			overflow = remainderOverflow(overflow, k, target, sorted[i].value)
+			continue
+		}
+
+		if err := kSumAll(rest, k-1, remaining, allowReuse, append(chosen[:len(chosen):len(chosen)], sorted[i]), found); err != nil && overflow == nil {
+			overflow = err
+		}
+	}
+
+	return overflow
+}
+
+// sortedEntries pairs each number with its position, sorted by value and then
+// position.
+func sortedEntries(nums []int) []entry {
+	entries := make([]entry, len(nums))
+	for i, num := range nums {
+		entries[i] = entry{num, i}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].value != entries[j].value {
+			return entries[i].value < entries[j].value
+		}
+		return entries[i].index < entries[j].index
+	})
+
+	return entries
+}
+
+// searchEntries returns the first position in sorted with a value of at least
+// target.
+func searchEntries(sorted []entry, target int) int {
+	return sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].value >= target
+	})
+}
+
+func newMatch(found []entry) Match {
+	sorted := make([]entry, len(found))
+	copy(sorted, found)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].index < sorted[j].index
+	})
+
+	m := Match{}
+	for _, e := range sorted {
+		m.Indices = append(m.Indices, e.index)
+		m.Entries = append(m.Entries, e.value)
+	}
+
+	return m
+}
+
+// minInt is the smallest int, whichever size ints are.
+const minInt = -1 << (bits.UintSize - 1)
+
+// addInt adds a and b, reporting false if the sum overflows.
+func addInt(a, b int) (int, bool) {
+	sum := a + b
+	return sum, (sum > a) == (b > 0)
+}
+
+// subInt subtracts b from a, reporting false if the difference overflows.
+func subInt(a, b int) (int, bool) {
+	diff := a - b
+	return diff, (diff < a) == (b > 0)
+}
+
+// mulInt multiplies a and b, reporting false if the product overflows.
+func mulInt(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	p := a * b
+	if p/b != a || (a == minInt && b == -1) {
+		return p, false
+	}
+	return p, true
+}
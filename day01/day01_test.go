@@ -0,0 +1,196 @@
+package day01
+
+import (
+	"aoc2020/solver/solvertest"
+	"aoc2020/utils/fileinput"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKSum(t *testing.T) {
+	expenses := []int{1721, 979, 366, 299, 675, 1456}
+
+	tests := []struct {
+		name    string
+		nums    []int
+		k       int
+		target  int
+		want    Match
+		wantErr bool
+	}{
+		{name: "pair", nums: expenses, k: 2, target: 2020, want: Match{Indices: []int{0, 3}, Entries: []int{1721, 299}}},
+		{name: "triple", nums: expenses, k: 3, target: 2020, want: Match{Indices: []int{1, 2, 4}, Entries: []int{979, 366, 675}}},
+		{name: "four", nums: expenses, k: 4, target: 3365, want: Match{Indices: []int{0, 1, 2, 3}, Entries: []int{1721, 979, 366, 299}}},
+		{name: "single", nums: expenses, k: 1, target: 366, want: Match{Indices: []int{2}, Entries: []int{366}}},
+		{name: "negative entries", nums: []int{-50, 100, 2070, 40}, k: 2, target: 2020, want: Match{Indices: []int{0, 2}, Entries: []int{-50, 2070}}},
+		{name: "no match", nums: expenses, k: 4, target: 2299, wantErr: true},
+		{name: "entry not reused", nums: []int{1010, 5}, k: 2, target: 2020, wantErr: true},
+		{name: "too few entries", nums: []int{2020}, k: 2, target: 2020, wantErr: true},
+		{name: "invalid k", nums: expenses, k: 0, target: 2020, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KSum(tt.nums, tt.k, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KSum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KSum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKSumAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		nums       []int
+		k          int
+		target     int
+		allowReuse bool
+		want       []Match
+		wantErr    bool
+	}{
+		{
+			name:   "every pair",
+			nums:   []int{1000, 1020, 10, 2010, 1000},
+			k:      2,
+			target: 2020,
+			want: []Match{
+				{Indices: []int{0, 1}, Entries: []int{1000, 1020}},
+				{Indices: []int{1, 4}, Entries: []int{1020, 1000}},
+				{Indices: []int{2, 3}, Entries: []int{10, 2010}},
+			},
+		},
+		{
+			name:   "equal entries at different positions",
+			nums:   []int{1010, 1010, 1010},
+			k:      2,
+			target: 2020,
+			want: []Match{
+				{Indices: []int{0, 1}, Entries: []int{1010, 1010}},
+				{Indices: []int{0, 2}, Entries: []int{1010, 1010}},
+				{Indices: []int{1, 2}, Entries: []int{1010, 1010}},
+			},
+		},
+		{
+			name:    "reuse forbidden",
+			nums:    []int{1010, 5},
+			k:       2,
+			target:  2020,
+			wantErr: true,
+		},
+		{
+			name:       "reuse allowed",
+			nums:       []int{1010, 5, 2010, 1005},
+			k:          3,
+			target:     2020,
+			allowReuse: true,
+			want: []Match{
+				{Indices: []int{0, 1, 3}, Entries: []int{1010, 5, 1005}},
+				{Indices: []int{1, 1, 2}, Entries: []int{5, 5, 2010}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KSumAll(tt.nums, tt.k, tt.target, tt.allowReuse)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KSumAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KSumAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKSum_overflow(t *testing.T) {
+	const maxInt = int(^uint(0) >> 1)
+
+	// Pairs that overflow are passed over rather than wrapping round to the target
+	got, err := KSum([]int{maxInt, maxInt, 1, 2019}, 2, 2020)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 3}; !reflect.DeepEqual(got.Indices, want) {
+		t.Errorf("KSum() indices = %v, want %v", got.Indices, want)
+	}
+
+	// An overflowing remainder doesn't stop a match being found elsewhere
+	const minInt = -maxInt - 1
+	got, err = KSum([]int{minInt, 1000, 1010, 10}, 3, 2020)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got.Indices, want) {
+		t.Errorf("KSum() indices = %v, want %v", got.Indices, want)
+	}
+
+	matches, err := KSumAll([]int{minInt, 1000, 1010, 10}, 3, 2020, false)
+	if err != nil || len(matches) != 1 {
+		t.Errorf("KSumAll() = %v, %v, want one match", matches, err)
+	}
+
+	// With one entry left to find, an overflowing remainder can't match anything
+	if _, err := KSumAll([]int{minInt, 5, 6}, 2, 2020, false); err == nil || errors.Is(err, ErrOverflow) {
+		t.Errorf("KSumAll() error = %v, want no match", err)
+	}
+
+	if _, err := KSum([]int{-maxInt, 5, 6, 7}, 3, 2020); !errors.Is(err, ErrOverflow) {
+		t.Errorf("KSum() error = %v, want ErrOverflow", err)
+	}
+
+	if _, err := KSumAll([]int{-maxInt, 5, 6, 7}, 3, 2020, false); !errors.Is(err, ErrOverflow) {
+		t.Errorf("KSumAll() error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestMatch_Product(t *testing.T) {
+	got, err := Match{Entries: []int{979, 366, 675}}.Product()
+	if err != nil || got != 241861950 {
+		t.Errorf("Product() = %v, %v, want 241861950", got, err)
+	}
+
+	if _, err := (Match{Entries: []int{1 << 40, 1 << 40}}).Product(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Product() error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestKSumBig(t *testing.T) {
+	nums, err := fileinput.ReadBigInts(strings.NewReader("10000000000000000000000\n17\n-9999999999999999997980\n5\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := KSumBig(nums, 2, big.NewInt(2020))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{0, 2}; !reflect.DeepEqual(got.Indices, want) {
+		t.Errorf("KSumBig() indices = %v, want %v", got.Indices, want)
+	}
+	if want := "-99999999999999999979800000000000000000000000"; got.Product().String() != want {
+		t.Errorf("Product() = %v, want %v", got.Product(), want)
+	}
+
+	if _, err := KSumBig(nums, 3, big.NewInt(2020)); err == nil {
+		t.Error("KSumBig() error = nil, want no match")
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
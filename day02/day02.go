@@ -0,0 +1,197 @@
+//--- Day 2: Password Philosophy ---
+//
+// Your flight departs in a few days from the coastal airport; the easiest way down to the coast from here is via
+// toboggan.
+//
+// The shopkeeper at the North Pole Toboggan Rental Shop is having a bad day. "Something's wrong with our computers; we
+// can't log in!" You ask if you can take a look.
+//
+// Their password database seems to be a little corrupted: some of the passwords wouldn't have been allowed by the
+// Official Toboggan Corporate Policy that was in effect when they were chosen.
+//
+// To try to debug the problem, they have created a list (your puzzle input) of passwords (according to the corrupted
+// database) and the corporate policy when that password was set.
+//
+// For example, suppose you have the following list:
+//
+// 1-3 a: abcde
+// 1-3 b: cdefg
+// 2-9 c: ccccccccc
+//
+// Each line gives the password policy and then the password. The password policy indicates the lowest and highest
+// number of times a given letter must appear for the password to be valid. For example, 1-3 a means that the password
+// must contain a at least 1 time and at most 3 times.
+//
+// In the above example, 2 passwords are valid. The middle password, cdefg, is not; it contains no instances of b, but
+// needs at least 1. The first and third passwords are valid: they contain one a or nine c, both within the limits of
+// their respective policies.
+//
+// How many passwords are valid according to their policies?
+//
+// --- Part Two ---
+//
+// While it appears you validated the passwords correctly, they don't seem to be what the Official Toboggan Corporate
+// Authentication System is expecting.
+//
+// The shopkeeper suddenly realizes that he just accidentally explained the password policy rules from his old job at
+// the sled rental place down the street! The Official Toboggan Corporate Policy actually works a little differently.
+//
+// Each policy actually describes two positions in the password, where 1 means the first character, 2 means the second
+// character, and so on. (Be careful; Toboggan Corporate Policies have no concept of "index zero"!) Exactly one of these
+// positions must contain the given letter. Other occurrences of the letter are irrelevant for the purposes of policy
+// enforcement.
+//
+// Given the same example list from above:
+//
+// 1-3 a: abcde is valid: position 1 contains a and position 3 does not.
+// 1-3 b: cdefg is invalid: neither position 1 nor position 3 contains b.
+// 2-9 c: ccccccccc is invalid: both position 2 and position 9 contain c.
+//
+// How many passwords are valid according to the new interpretation of the policies?
+
+package day02
+
+import (
+	"aoc2020/solver"
+	"aoc2020/utils/fileinput"
+	"aoc2020/utils/str"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Entry is one line of the password database: a rule, such as "1-3 a", and
+// the password it applies to. How the rule's numbers are read depends on the
+// Policy: as a range of counts, or as 1-based positions in the password. Line
+// is the entry's 1-based line in the database it was read from, or 0 if it
+// wasn't read from one.
+type Entry struct {
+	Char     string
+	Low      int
+	High     int
+	Password string
+	Line     int
+}
+
+// String formats the entry as it appears in the database.
+func (e Entry) String() string {
+	return fmt.Sprintf("%v-%v %v: %v", e.Low, e.High, e.Char, e.Password)
+}
+
+// Example entry: "8-11 m: wzxcmwgmmvmgq". The character and password may be any
+// Unicode text, so long as the character is a single grapheme.
+var entryLineRegexp = regexp.MustCompile(`^(?P<minTimes>\d+)-(?P<maxTimes>\d+)\s(?P<character>\S+):\s(?P<password>\S+)$`)
+
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     2,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInput(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return CountValid(input.([]Entry), CountRange{}), nil
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return CountValid(input.([]Entry), ExactlyOnePosition{}), nil
+	},
+}
+
+// parseInput reads the password database, failing at the first malformed line.
+func parseInput(r io.Reader) ([]Entry, error) {
+	entries, _, err := parse(r, true)
+	return entries, err
+}
+
+// ParseLenient reads a password database, skipping any malformed lines. Each
+// skipped line is returned as a warning giving its line number and content.
+func ParseLenient(r io.Reader) (entries []Entry, warnings []*fileinput.ParseError, err error) {
+	return parse(r, false)
+}
+
+func parse(r io.Reader, strict bool) (entries []Entry, warnings []*fileinput.ParseError, err error) {
+	scanner := fileinput.NewScanner(r, "\n")
+
+	for scanner.Scan() {
+		entry, err := entryFromString(scanner.Text())
+
+		if err != nil {
+			if strict {
+				return entries, warnings, scanner.ParseError(err)
+			}
+
+			warnings = append(warnings, scanner.ParseError(err))
+			continue
+		}
+
+		entry.Line = scanner.Line()
+		entries = append(entries, entry)
+	}
+
+	return entries, warnings, scanner.Err()
+}
+
+func entryFromString(line string) (Entry, error) {
+	parts, err := parseEntry(line)
+
+	if err != nil {
+		return Entry{}, err
+	}
+
+	minTimes, err := strconv.Atoi(parts["minTimes"])
+
+	if err != nil {
+		return Entry{}, err
+	}
+
+	maxTimes, err := strconv.Atoi(parts["maxTimes"])
+
+	if err != nil {
+		return Entry{}, err
+	}
+
+	return Entry{
+		Char:     parts["character"],
+		Low:      minTimes,
+		High:     maxTimes,
+		Password: parts["password"],
+	}, nil
+}
+
+// Thanks, https://stackoverflow.com/a/53587770/308563
+func parseEntry(line string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	if !utf8.ValidString(line) {
+		return result, errors.New("entry isn't valid UTF-8")
+	}
+
+	if !entryLineRegexp.MatchString(line) {
+		return result, errors.New(`malformed entry, expected "min-max char: password"`)
+	}
+
+	match := entryLineRegexp.FindStringSubmatch(line)
+
+	for i, val := range match {
+		key := entryLineRegexp.SubexpNames()[i]
+		result[key] = val
+	}
+
+	if str.GraphemeCount(result["character"]) != 1 {
+		return result, fmt.Errorf("policy character %q isn't a single character", result["character"])
+	}
+
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package day02
+
+import (
+	"aoc2020/solver/solvertest"
+	"aoc2020/utils/fileinput"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPolicies(t *testing.T) {
+	tests := []struct {
+		spec  string
+		entry Entry
+		want  string
+	}{
+		{"count-range", Entry{"a", 1, 3, "abcde", 0}, ""},
+		{"count-range", Entry{"m", 8, 11, "wzxcmwgmmvmgq", 0}, `'m' appears 4 times, need 8-11`},
+		{"exactly-one-position", Entry{"a", 1, 3, "abcde", 0}, ""},
+		{"exactly-one-position", Entry{"c", 2, 9, "ccccccccc", 0}, `'c' is at both positions 2 and 9`},
+		{"exactly-one-position", Entry{"b", 1, 3, "cdefg", 0}, `'b' is at neither position 1 nor 3`},
+		{"exactly-one-position", Entry{"a", 1, 9, "abcde", 0}, "position 9 is past the end of the 5-character password"},
+		{"none-of-positions", Entry{"b", 1, 3, "cdefg", 0}, ""},
+		{"none-of-positions", Entry{"a", 1, 3, "abcde", 0}, `'a' is at position 1`},
+		{"regex:^[a-z]{5,}$", Entry{"a", 1, 3, "abcde", 0}, ""},
+		{"regex:^[a-z]{6,}$", Entry{"a", 1, 3, "abcde", 0}, "password doesn't match ^[a-z]{6,}$"},
+		{"min-entropy:8", Entry{"a", 1, 3, "abcd", 0}, ""},
+		{"min-entropy:8", Entry{"c", 2, 9, "ccccccccc", 0}, "password has 0.0 bits of entropy, need 8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			p, err := NewPolicy(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := ""
+			if err := p.Check(tt.entry); err != nil {
+				got = err.Error()
+			}
+
+			if got != tt.want {
+				t.Errorf("Check(%v) = %q, want %q", tt.entry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicies_unicode(t *testing.T) {
+	tests := []struct {
+		spec  string
+		entry Entry
+		want  string
+	}{
+		{"count-range", Entry{"é", 2, 3, "épée", 0}, ""},
+		{"count-range", Entry{"e", 1, 1, "e\u0301te\u0301", 0}, "'e' appears 0 times, need 1-1"},
+		{"count-range", Entry{"🎄", 2, 2, "🎄x🎄", 0}, ""},
+		{"exactly-one-position", Entry{"è", 3, 5, "crème", 0}, ""},
+		{"exactly-one-position", Entry{"m", 4, 5, "crème", 0}, ""},
+		{"exactly-one-position", Entry{"👩\u200d💻", 1, 3, "👩\u200d💻ab", 0}, ""},
+		{"exactly-one-position", Entry{"a", 2, 4, "👍🏽a🇫🇷", 0}, "position 4 is past the end of the 3-character password"},
+		{"none-of-positions", Entry{"e", 4, 1, "cafe\u0301", 0}, ""},
+		{"min-entropy:2", Entry{"é", 1, 1, "éèêë", 0}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			p, err := NewPolicy(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := ""
+			if err := p.Check(tt.entry); err != nil {
+				got = err.Error()
+			}
+
+			if got != tt.want {
+				t.Errorf("Check(%v) = %q, want %q", tt.entry, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseInput_unicode(t *testing.T) {
+	input := "1-3 é: crème\n" +
+		"2-4 🎄: 🎄🎅🎄\n" +
+		"1-2 e\u0301: cafe\u0301\n"
+
+	got, err := parseInput(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Entry{
+		{"é", 1, 3, "crème", 1},
+		{"🎄", 2, 4, "🎄🎅🎄", 2},
+		{"e\u0301", 1, 2, "cafe\u0301", 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseInput() = %q, want %q", got, want)
+	}
+}
+
+const malformedInput = "1-3 a: abcde\n" +
+	"1-3 ab: abcde\n" +
+	"\n" +
+	"1-3 b: cdefg\n" +
+	"1-3 \xff: abc\n" +
+	"1 c: ccc\n" +
+	"2-9 c: ccccccccc\n"
+
+func Test_parseInput_malformed(t *testing.T) {
+	_, err := parseInput(strings.NewReader(malformedInput))
+
+	var parseErr *fileinput.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("parseInput() error = %v, want a ParseError", err)
+	}
+
+	if parseErr.Line != 2 || parseErr.Text != "1-3 ab: abcde" {
+		t.Errorf("parseInput() error = %v, want line 2, \"1-3 ab: abcde\"", err)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	entries, warnings, err := ParseLenient(strings.NewReader(malformedInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Entry{{"a", 1, 3, "abcde", 1}, {"b", 1, 3, "cdefg", 4}, {"c", 2, 9, "ccccccccc", 7}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("ParseLenient() entries = %v, want %v", entries, want)
+	}
+
+	var lines []int
+	for _, w := range warnings {
+		lines = append(lines, w.Line)
+	}
+	if want := []int{2, 5, 6}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("ParseLenient() warnings on lines %v, want %v", lines, want)
+	}
+}
+
+func TestNewPolicy_invalid(t *testing.T) {
+	for _, spec := range []string{"", "length", "count-range:3", "regex:[a-z", "min-entropy:lots"} {
+		if _, err := NewPolicy(spec); err == nil {
+			t.Errorf("NewPolicy(%q) error = nil, want an error", spec)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	entries := []Entry{{"a", 1, 3, "abcde", 0}, {"b", 1, 3, "cdefg", 0}}
+
+	got, err := Report(entries, "count-range", "exactly-one-position")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []EntryReport{
+		{Number: 1, Entry: entries[0], Verdicts: []Verdict{
+			{Policy: "count-range", Valid: true},
+			{Policy: "exactly-one-position", Valid: true},
+		}},
+		{Number: 2, Entry: entries[1], Verdicts: []Verdict{
+			{Policy: "count-range", Reason: `'b' appears 0 times, need 1-3`},
+			{Policy: "exactly-one-position", Reason: `'b' is at neither position 1 nor 3`},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Report() = %v, want %v", got, want)
+	}
+
+	if _, err := Report(entries, "length"); err == nil {
+		t.Error("Report() error = nil, want an unknown policy error")
+	}
+
+	// Entries read leniently keep the numbers of their lines in the database
+	parsed, _, err := ParseLenient(strings.NewReader("1-3 a: abcde\nbad line\n1-3 b: cdefg\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = Report(parsed, "count-range")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Number != 1 || got[1].Number != 3 {
+		t.Errorf("Report() of lenient entries = %v, want numbers 1 and 3", got)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
@@ -0,0 +1,265 @@
+package day02
+
+import (
+	"aoc2020/utils/str"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Policy checks a password against its entry's rule, returning an error that
+// explains why the password isn't valid.
+type Policy interface {
+	Check(e Entry) error
+}
+
+// policies are the policies that can be chosen by name. Some need an argument,
+// given after a colon, such as "min-entropy:20".
+var policies = map[string]func(arg string) (Policy, error){
+	"count-range":          withoutArgument("count-range", CountRange{}),
+	"exactly-one-position": withoutArgument("exactly-one-position", ExactlyOnePosition{}),
+	"none-of-positions":    withoutArgument("none-of-positions", NoneOfPositions{}),
+	"regex": func(arg string) (Policy, error) {
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("policy regex: %w", err)
+		}
+		return Regexp{re}, nil
+	},
+	"min-entropy": func(arg string) (Policy, error) {
+		bits, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("policy min-entropy: invalid number of bits %q", arg)
+		}
+		return MinEntropy{bits}, nil
+	},
+}
+
+// withoutArgument makes a policy that takes no argument.
+func withoutArgument(name string, p Policy) func(arg string) (Policy, error) {
+	return func(arg string) (Policy, error) {
+		if arg != "" {
+			return nil, fmt.Errorf("policy %v doesn't take an argument, got %q", name, arg)
+		}
+		return p, nil
+	}
+}
+
+// PolicyNames returns the names NewPolicy accepts, in alphabetical order.
+func PolicyNames() []string {
+	var names []string
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewPolicy returns the policy named by spec, which is a policy name followed
+// by its argument, if it takes one: "count-range", "regex:^[a-z]+$" or
+// "min-entropy:20".
+func NewPolicy(spec string) (Policy, error) {
+	parts := strings.SplitN(spec, ":", 2)
+
+	newPolicy, ok := policies[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown policy %q, expected one of %v", parts[0], strings.Join(PolicyNames(), ", "))
+	}
+
+	var arg string
+	if len(parts) == 2 {
+		arg = parts[1]
+	}
+
+	return newPolicy(arg)
+}
+
+// CountValid returns how many of the entries' passwords are valid under p.
+func CountValid(entries []Entry, p Policy) int {
+	count := 0
+
+	for _, e := range entries {
+		if p.Check(e) == nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+// CountRange requires the character to appear in the password at least Low and
+// at most High times. This is the sled rental place's policy.
+type CountRange struct{}
+
+func (CountRange) Check(e Entry) error {
+	count := 0
+	for _, g := range str.Graphemes(e.Password) {
+		if g == e.Char {
+			count++
+		}
+	}
+
+	if count < e.Low || count > e.High {
+		return fmt.Errorf("'%v' appears %v times, need %v-%v", e.Char, count, e.Low, e.High)
+	}
+	return nil
+}
+
+// ExactlyOnePosition requires the character to be at exactly one of the
+// 1-based positions Low and High. This is the Toboggan Corporate policy.
+type ExactlyOnePosition struct{}
+
+func (ExactlyOnePosition) Check(e Entry) error {
+	at0, err := charIsAt(e, e.Low)
+	if err != nil {
+		return err
+	}
+
+	at1, err := charIsAt(e, e.High)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case at0 && at1:
+		return fmt.Errorf("'%v' is at both positions %v and %v", e.Char, e.Low, e.High)
+	case !at0 && !at1:
+		return fmt.Errorf("'%v' is at neither position %v nor %v", e.Char, e.Low, e.High)
+	}
+	return nil
+}
+
+// NoneOfPositions forbids the character from being at either of the 1-based
+// positions Low and High.
+type NoneOfPositions struct{}
+
+func (NoneOfPositions) Check(e Entry) error {
+	for _, pos := range []int{e.Low, e.High} {
+		at, err := charIsAt(e, pos)
+		if err != nil {
+			return err
+		}
+
+		if at {
+			return fmt.Errorf("'%v' is at position %v", e.Char, pos)
+		}
+	}
+	return nil
+}
+
+// charIsAt reports whether the entry's character is at the 1-based position pos
+// of its password, counting graphemes so that accented letters and emoji take
+// up one position each.
+func charIsAt(e Entry, pos int) (bool, error) {
+	if pos < 1 {
+		return false, fmt.Errorf("position %v is before the start of the password", pos)
+	}
+
+	char, err := str.GraphemeAt(e.Password, pos-1)
+	if err != nil {
+		return false, fmt.Errorf("position %v is past the end of the %v-character password", pos, str.GraphemeCount(e.Password))
+	}
+
+	return char == e.Char, nil
+}
+
+// Regexp requires the password to match a regular expression, ignoring the
+// entry's rule. Anchor the expression to match the whole password.
+type Regexp struct {
+	Re *regexp.Regexp
+}
+
+func (p Regexp) Check(e Entry) error {
+	if !p.Re.MatchString(e.Password) {
+		return fmt.Errorf("password doesn't match %v", p.Re)
+	}
+	return nil
+}
+
+// MinEntropy requires the password to carry at least Bits of Shannon entropy,
+// measured from how often each of its characters appears, ignoring the entry's
+// rule.
+type MinEntropy struct {
+	Bits float64
+}
+
+func (p MinEntropy) Check(e Entry) error {
+	if bits := entropy(e.Password); bits < p.Bits {
+		return fmt.Errorf("password has %.1f bits of entropy, need %v", bits, p.Bits)
+	}
+	return nil
+}
+
+// entropy is the Shannon entropy of s in bits: its length times the average
+// information carried by each of its graphemes.
+func entropy(s string) float64 {
+	counts := map[string]int{}
+	total := 0
+	for _, g := range str.Graphemes(s) {
+		counts[g]++
+		total++
+	}
+
+	bits := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		bits -= float64(count) * math.Log2(p)
+	}
+
+	return bits
+}
+
+// Verdict is the outcome of checking a password against one policy. Reason
+// explains why the password failed, and is empty if it passed.
+type Verdict struct {
+	Policy string
+	Valid  bool
+	Reason string
+}
+
+// EntryReport holds an entry's verdicts under each policy it was checked
+// against. Number is the entry's 1-based line in the database, so that it still
+// matches the database when malformed lines have been skipped, or its position
+// among the entries if it wasn't read from one.
+type EntryReport struct {
+	Number   int
+	Entry    Entry
+	Verdicts []Verdict
+}
+
+// Report checks every entry against each of the policies named by specs, as
+// accepted by NewPolicy, so it can be seen which passwords failed and why.
+func Report(entries []Entry, specs ...string) ([]EntryReport, error) {
+	var checks []Policy
+	for _, spec := range specs {
+		p, err := NewPolicy(spec)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, p)
+	}
+
+	reports := make([]EntryReport, len(entries))
+	for i, e := range entries {
+		number := e.Line
+		if number == 0 {
+			number = i + 1
+		}
+
+		reports[i] = EntryReport{Number: number, Entry: e}
+
+		for j, p := range checks {
+			v := Verdict{Policy: specs[j], Valid: true}
+			if err := p.Check(e); err != nil {
+				v.Valid = false
+				v.Reason = err.Error()
+			}
+			reports[i].Verdicts = append(reports[i].Verdicts, v)
+		}
+	}
+
+	return reports, nil
+}
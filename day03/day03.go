@@ -79,29 +79,37 @@
 // In the above example, these slopes would find 2, 7, 3, 4, and 2 tree(s) respectively; multiplied together, these
 // produce the answer 336.
 
-package main
+package day03
 
 import (
-	"fmt"
-	"io/ioutil"
-	"log"
-	"strings"
+	"aoc2020/solver"
+	_ "embed"
+	"io"
 )
 
 type Row []string
 
-func main() {
-	rows, err := parseInput("day03/input.txt")
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	part1Trees := part1(rows)
-	part2Trees := part2(rows)
-
-	fmt.Printf("Part 1: %v trees\n", part1Trees)
-	fmt.Printf("Part 2: %v trees\n", part2Trees)
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     3,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInput(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]Row)), nil
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.([]Row)), nil
+	},
 }
 
 func part1(rows []Row) int {
@@ -109,10 +117,7 @@ func part1(rows []Row) int {
 }
 
 func part2(rows []Row) int {
-	traversals := []struct {
-		downBy  int
-		rightBy int
-	}{
+	traversals := []Slope{
 		{1, 1},
 		{1, 3},
 		{1, 5},
@@ -123,7 +128,7 @@ func part2(rows []Row) int {
 	m := 1
 
 	for _, t := range traversals {
-		m *= treesForTraversal(rows, t.downBy, t.rightBy)
+		m *= treesForTraversal(rows, t.Down, t.Right)
 	}
 
 	return m
@@ -143,34 +148,12 @@ func treesForTraversal(rows []Row, downBy int, rightBy int) int {
 	return treesCount
 }
 
+// CellAt returns the cell at index, wrapping round in either direction as the
+// map repeats.
 func (r Row) CellAt(index int) string {
-	return r[index%len(r)]
+	return r[(index%len(r)+len(r))%len(r)]
 }
 
-func parseInput(fileName string) ([]Row, error) {
-	data, err := ioutil.ReadFile(fileName)
-
-	if err != nil {
-		return make([]Row, 0), err
-	}
-
-	lines := strings.Split(string(data), "\n")
-
-	var rows []Row
-
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		var row []string
-
-		for _, r := range []rune(line) {
-			row = append(row, string(r))
-		}
-
-		rows = append(rows, row)
-	}
-
-	return rows, nil
+func parseInput(r io.Reader) ([]Row, error) {
+	return ParseMap(r, DefaultLegend)
 }
@@ -0,0 +1,213 @@
+package day03
+
+import (
+	"aoc2020/solver/solvertest"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const exampleMap = "..##.......\n#...#...#..\n.#....#..#.\n..#.#...#.#\n.#...##..#.\n..#.##.....\n" +
+	".#.#.#....#\n.#........#\n#.##...#...\n#...##....#\n.#..#...#.#\n"
+
+func TestSearchSlopes(t *testing.T) {
+	rows, err := parseInput(strings.NewReader(exampleMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bounds := SlopeBounds{MinDown: 1, MaxDown: 2, MinRight: -1, MaxRight: 3}
+
+	fewest, err := SearchSlopes(rows, bounds, FewestTrees)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantBest := []SlopeTrees{{Slope{1, 2}, 1}, {Slope{2, 0}, 1}, {Slope{2, 2}, 1}}
+	if !reflect.DeepEqual(fewest.Best, wantBest) {
+		t.Errorf("SearchSlopes(FewestTrees).Best = %v, want %v", fewest.Best, wantBest)
+	}
+
+	wantRanking := []SlopeTrees{
+		{Slope{1, 2}, 1}, {Slope{2, 0}, 1}, {Slope{2, 2}, 1},
+		{Slope{1, 1}, 2}, {Slope{2, -1}, 2}, {Slope{2, 1}, 2}, {Slope{2, 3}, 2},
+		{Slope{1, 0}, 3}, {Slope{1, -1}, 5}, {Slope{1, 3}, 7},
+	}
+	if !reflect.DeepEqual(fewest.Ranking, wantRanking) {
+		t.Errorf("SearchSlopes(FewestTrees).Ranking = %v, want %v", fewest.Ranking, wantRanking)
+	}
+
+	most, err := SearchSlopes(rows, bounds, MostTrees)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []SlopeTrees{{Slope{1, 3}, 7}}; !reflect.DeepEqual(most.Best, want) {
+		t.Errorf("SearchSlopes(MostTrees).Best = %v, want %v", most.Best, want)
+	}
+
+	for _, bounds := range []SlopeBounds{{0, 2, 1, 3}, {2, 1, 1, 3}, {1, 2, 3, 1}} {
+		if _, err := SearchSlopes(rows, bounds, FewestTrees); err == nil {
+			t.Errorf("SearchSlopes(%v) error = nil, want an error", bounds)
+		}
+	}
+}
+
+func TestRenderASCII(t *testing.T) {
+	rows, err := parseInput(strings.NewReader(exampleMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// As in the puzzle text, but with the starting cell marked
+	want := `O.##.........##.........##.......
+#..O#...#..#...#...#..#...#...#..
+.#....X..#..#....#..#..#....#..#.
+..#.#...#O#..#.#...#.#..#.#...#.#
+.#...##..#..X...##..#..#...##..#.
+..#.##.......#.X#.......#.##.....
+.#.#.#....#.#.#.#.O..#.#.#.#....#
+.#........#.#........X.#........#
+#.##...#...#.##...#...#.X#...#...
+#...##....##...##....##...#X....#
+.#..#...#.#.#..#...#.#.#..#...X.#
+`
+
+	var b strings.Builder
+	if err := RenderASCII(&b, rows, Slope{Down: 1, Right: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := b.String(); got != want {
+		t.Errorf("RenderASCII() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestRenderASCII_left(t *testing.T) {
+	rows, err := parseInput(strings.NewReader("..#\n.#.\n.#.\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "..#..#O.#\n.#..X..#.\n.#O.#..#.\n"
+
+	var b strings.Builder
+	if err := RenderASCII(&b, rows, Slope{Down: 1, Right: -2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := b.String(); got != want {
+		t.Errorf("RenderASCII() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	rows, err := parseInput(strings.NewReader(exampleMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := RenderSVG(&b, rows, []Slope{{1, 3}, {2, 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The SVG must be well-formed XML
+	dec := xml.NewDecoder(strings.NewReader(b.String()))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("RenderSVG() isn't well-formed: %v", err)
+		}
+	}
+
+	for _, want := range []string{"right 3, down 1: 7 trees", "right 1, down 2: 2 trees", svgColours[0], svgColours[1]} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("RenderSVG() doesn't contain %q", want)
+		}
+	}
+
+	if err := RenderSVG(&b, rows, nil); err == nil {
+		t.Error("RenderSVG() error = nil, want an error for no slopes")
+	}
+}
+
+func TestParseLegend(t *testing.T) {
+	got, err := ParseLegend(strings.NewReader(". open 0\n# tree 1\nT tree 1\n~ water 2.5\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Legend{".": {"open", 0}, "#": {"tree", 1}, "T": {"tree", 1}, "~": {"water", 2.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLegend() = %v, want %v", got, want)
+	}
+
+	for _, input := range []string{"", "# tree\n", "## tree 1\n", "# tree 1\n# rock 2\n", "# tree one\n", "# tree 1\nT tree 2\n"} {
+		if _, err := ParseLegend(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseLegend(%q) error = nil, want an error", input)
+		}
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	legend := Legend{".": {"open", 0}, "#": {"tree", 1}, "T": {"tree", 1}, "~": {"water", 2.5}}
+
+	rows, err := ParseMap(strings.NewReader(".#~\n~.T\n#~.\n.~#\n"), legend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Traverse(rows, Slope{Down: 1, Right: 2}, legend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Traversal{
+		Slope: Slope{Down: 1, Right: 2},
+		ByTerrain: []TerrainCount{
+			{Terrain: Terrain{"open", 0}, Count: 2, TotalCost: 0},
+			{Terrain: Terrain{"tree", 1}, Count: 1, TotalCost: 1},
+			{Terrain: Terrain{"water", 2.5}, Count: 1, TotalCost: 2.5},
+		},
+		TotalCost: 3.5,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Traverse() = %v, want %v", got, want)
+	}
+
+	if _, err := ParseMap(strings.NewReader(".#x\n"), legend); err == nil {
+		t.Error("ParseMap() error = nil, want an error for a cell not in the legend")
+	}
+}
+
+func TestTraverse_defaultLegend(t *testing.T) {
+	rows, err := parseInput(strings.NewReader(exampleMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Traverse(rows, Slope{Down: 1, Right: 3}, DefaultLegend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.TotalCost != 7 || got.ByTerrain[1].Count != 7 || got.ByTerrain[0].Count != 4 {
+		t.Errorf("Traverse() = %v, want 4 open and 7 trees, costing 7", got)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
@@ -0,0 +1,210 @@
+package day03
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// position is a cell on the map, with col counting across the repeats of the
+// map to either side.
+type position struct {
+	row int
+	col int
+}
+
+// path returns the cells visited going down the map at a slope, starting at the
+// top left. These are the cells treesForTraversal checks for trees.
+func path(rows []Row, s Slope) []position {
+	var visited []position
+
+	for row, col := 0, 0; row < len(rows); row, col = row+s.Down, col+s.Right {
+		visited = append(visited, position{row, col})
+	}
+
+	return visited
+}
+
+// tileColumns returns the range of columns, from first up to but not
+// including last, covering whole repeats of the map and every cell visited on
+// the paths.
+func tileColumns(width int, paths [][]position) (first int, last int) {
+	minCol, maxCol := 0, 0
+
+	for _, p := range paths {
+		for _, pos := range p {
+			if pos.col < minCol {
+				minCol = pos.col
+			}
+			if pos.col > maxCol {
+				maxCol = pos.col
+			}
+		}
+	}
+
+	first = floorDiv(minCol, width) * width
+	last = (floorDiv(maxCol, width) + 1) * width
+
+	return first, last
+}
+
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+	return a / b
+}
+
+func checkRenderable(rows []Row, slopes []Slope) error {
+	if len(rows) == 0 {
+		return errors.New("map is empty")
+	}
+
+	for _, s := range slopes {
+		if s.Down < 1 {
+			return fmt.Errorf("slope right %v, down %v must go down at least one row", s.Right, s.Down)
+		}
+	}
+
+	return nil
+}
+
+// RenderASCII writes the map with the cells visited at a slope marked, as in
+// the puzzle: O where the cell is open and X where there's a tree. The starting
+// cell is marked too, since it's counted. The map is repeated sideways as far
+// as the path goes.
+func RenderASCII(w io.Writer, rows []Row, s Slope) error {
+	if err := checkRenderable(rows, []Slope{s}); err != nil {
+		return err
+	}
+
+	visited := path(rows, s)
+	first, last := tileColumns(len(rows[0]), [][]position{visited})
+
+	marks := map[position]bool{}
+	for _, pos := range visited {
+		marks[pos] = true
+	}
+
+	for i, row := range rows {
+		var b strings.Builder
+
+		for col := first; col < last; col++ {
+			cell := row.CellAt(col)
+
+			switch {
+			case !marks[position{i, col}]:
+				b.WriteString(cell)
+			case cell == "#":
+				b.WriteString("X")
+			default:
+				b.WriteString("O")
+			}
+		}
+
+		if _, err := fmt.Fprintln(w, b.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// svgCellSize is the width and height of a map cell in an SVG, in pixels.
+const svgCellSize = 10
+
+// svgColours are the colours given to each slope's path in an SVG, in turn.
+var svgColours = []string{"#e6194b", "#4363d8", "#f58231", "#911eb4", "#42d4f4", "#f032e6", "#9a6324", "#000075"}
+
+// RenderSVG writes an SVG of the map with each slope's path drawn over it in its
+// own colour. Cells visited on a path are circled, filled in where there's a
+// tree. A legend gives each slope's colour and the trees it hits.
+func RenderSVG(w io.Writer, rows []Row, slopes []Slope) error {
+	if err := checkRenderable(rows, slopes); err != nil {
+		return err
+	}
+
+	if len(slopes) == 0 {
+		return errors.New("no slopes to render")
+	}
+
+	var paths [][]position
+	for _, s := range slopes {
+		paths = append(paths, path(rows, s))
+	}
+
+	width := len(rows[0])
+	first, last := tileColumns(width, paths)
+
+	legendHeight := (len(slopes) + 1) * 2 * svgCellSize
+	mapWidth := (last - first) * svgCellSize
+	mapHeight := len(rows) * svgCellSize
+
+	// Leave room for the legend beside a narrow map
+	svgWidth := mapWidth
+	if svgWidth < 30*svgCellSize {
+		svgWidth = 30 * svgCellSize
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" font-family="sans-serif" font-size="%v">`+"\n",
+		svgWidth, legendHeight+mapHeight, svgCellSize+2)
+
+	for i, s := range slopes {
+		y := (i + 1) * 2 * svgCellSize
+		fmt.Fprintf(&b, `<rect x="0" y="%v" width="%v" height="%v" fill="%v"/>`+"\n", y-svgCellSize, svgCellSize, svgCellSize, svgColour(i))
+		fmt.Fprintf(&b, `<text x="%v" y="%v">right %v, down %v: %v trees</text>`+"\n", 2*svgCellSize, y, s.Right, s.Down, treesForTraversal(rows, s.Down, s.Right))
+	}
+
+	// The map is drawn once as a pattern, which repeats sideways for free
+	fmt.Fprintf(&b, `<g transform="translate(%v %v)">`+"\n", -first*svgCellSize, legendHeight)
+	fmt.Fprintln(&b, `<defs>`)
+	fmt.Fprintf(&b, `<pattern id="map" patternUnits="userSpaceOnUse" width="%v" height="%v">`+"\n", width*svgCellSize, mapHeight)
+	for i, row := range rows {
+		for col, cell := range row {
+			if cell == "#" {
+				fmt.Fprintf(&b, `<rect x="%v" y="%v" width="%v" height="%v" fill="#2d6a4f"/>`+"\n", col*svgCellSize, i*svgCellSize, svgCellSize, svgCellSize)
+			}
+		}
+	}
+	fmt.Fprintln(&b, `</pattern>`)
+	fmt.Fprintln(&b, `</defs>`)
+	fmt.Fprintf(&b, `<rect x="%v" y="0" width="%v" height="%v" fill="url(#map)" stroke="#999"/>`+"\n", first*svgCellSize, mapWidth, mapHeight)
+
+	for i, p := range paths {
+		colour := svgColour(i)
+
+		var points []string
+		for _, pos := range p {
+			x, y := svgCentre(pos)
+			points = append(points, fmt.Sprintf("%v,%v", x, y))
+		}
+		fmt.Fprintf(&b, `<polyline points="%v" fill="none" stroke="%v" stroke-width="2"/>`+"\n", strings.Join(points, " "), colour)
+
+		for _, pos := range p {
+			fill := "white"
+			if rows[pos.row].CellAt(pos.col) == "#" {
+				fill = colour
+			}
+
+			x, y := svgCentre(pos)
+			fmt.Fprintf(&b, `<circle cx="%v" cy="%v" r="%v" fill="%v" stroke="%v"/>`+"\n", x, y, svgCellSize*2/5, fill, colour)
+		}
+	}
+
+	fmt.Fprintln(&b, `</g>`)
+	fmt.Fprintln(&b, `</svg>`)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func svgColour(i int) string {
+	return svgColours[i%len(svgColours)]
+}
+
+func svgCentre(pos position) (x int, y int) {
+	return pos.col*svgCellSize + svgCellSize/2, pos.row*svgCellSize + svgCellSize/2
+}
@@ -0,0 +1,86 @@
+package day03
+
+import (
+	"errors"
+	"sort"
+)
+
+// Slope is a direction down the map: Down rows for every Right columns. A
+// negative Right heads left, wrapping round just as the map repeats to the
+// right.
+type Slope struct {
+	Down  int
+	Right int
+}
+
+// SlopeBounds limits a slope search to every slope with Down between MinDown
+// and MaxDown, and Right between MinRight and MaxRight, inclusive.
+type SlopeBounds struct {
+	MinDown  int
+	MaxDown  int
+	MinRight int
+	MaxRight int
+}
+
+// SlopeTrees is the number of trees hit going down the map at a slope.
+type SlopeTrees struct {
+	Slope
+	Trees int
+}
+
+// Goal is what a slope search looks for.
+type Goal int
+
+const (
+	FewestTrees Goal = iota
+	MostTrees
+)
+
+// SlopeSearch is the outcome of a slope search. Ranking holds every slope
+// searched, best first, and Best holds the slopes tied at the top of it.
+type SlopeSearch struct {
+	Best    []SlopeTrees
+	Ranking []SlopeTrees
+}
+
+// SearchSlopes counts the trees hit at every slope within bounds, ranking them
+// by goal. Slopes hitting the same number of trees are ordered by Down and then
+// by Right.
+func SearchSlopes(rows []Row, bounds SlopeBounds, goal Goal) (SlopeSearch, error) {
+	if bounds.MinDown < 1 {
+		return SlopeSearch{}, errors.New("slopes must go down at least one row")
+	}
+
+	if bounds.MinDown > bounds.MaxDown || bounds.MinRight > bounds.MaxRight {
+		return SlopeSearch{}, errors.New("slope bounds are empty")
+	}
+
+	if len(rows) == 0 {
+		return SlopeSearch{}, errors.New("map is empty")
+	}
+
+	var ranking []SlopeTrees
+
+	for down := bounds.MinDown; down <= bounds.MaxDown; down++ {
+		for right := bounds.MinRight; right <= bounds.MaxRight; right++ {
+			ranking = append(ranking, SlopeTrees{
+				Slope: Slope{down, right},
+				Trees: treesForTraversal(rows, down, right),
+			})
+		}
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if goal == MostTrees {
+			return ranking[i].Trees > ranking[j].Trees
+		}
+		return ranking[i].Trees < ranking[j].Trees
+	})
+
+	best := 1
+	for best < len(ranking) && ranking[best].Trees == ranking[0].Trees {
+		best++
+	}
+
+	return SlopeSearch{Best: ranking[:best], Ranking: ranking}, nil
+}
@@ -0,0 +1,172 @@
+package day03
+
+import (
+	"aoc2020/utils/fileinput"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Terrain is a kind of cell on the map, and what it costs to pass through.
+type Terrain struct {
+	Name string
+	Cost float64
+}
+
+// Legend maps each character that may appear on a map to its terrain. Several
+// characters may share a terrain, so long as they give it the same cost.
+type Legend map[string]Terrain
+
+// DefaultLegend is the puzzle's legend. Each tree costs one and open squares
+// are free, so a traversal's cost is the number of trees hit.
+var DefaultLegend = Legend{
+	".": {Name: "open", Cost: 0},
+	"#": {Name: "tree", Cost: 1},
+}
+
+// ParseLegend reads a legend with one terrain per line, giving its character,
+// name and cost, such as "# tree 1" or "~ water 2.5".
+func ParseLegend(r io.Reader) (Legend, error) {
+	legend := Legend{}
+
+	err := fileinput.ReadEach(r, "\n", func(line string) error {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return errors.New(`expected "char name cost"`)
+		}
+
+		char, name := fields[0], fields[1]
+
+		if utf8.RuneCountInString(char) != 1 {
+			return fmt.Errorf("terrain character %q isn't a single character", char)
+		}
+
+		if _, ok := legend[char]; ok {
+			return fmt.Errorf("terrain character %q is already in the legend", char)
+		}
+
+		cost, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return fmt.Errorf("invalid cost %q", fields[2])
+		}
+
+		for _, t := range legend {
+			if t.Name == name && t.Cost != cost {
+				return fmt.Errorf("terrain %v already costs %v", name, t.Cost)
+			}
+		}
+
+		legend[char] = Terrain{Name: name, Cost: cost}
+		return nil
+	})
+
+	if err == nil && len(legend) == 0 {
+		err = errors.New("legend is empty")
+	}
+
+	return legend, err
+}
+
+// cells returns the legend's characters, in order, for ReadGrid to allow.
+func (l Legend) cells() string {
+	var chars []string
+	for char := range l {
+		chars = append(chars, char)
+	}
+	sort.Strings(chars)
+	return strings.Join(chars, "")
+}
+
+// ParseMap reads a map whose cells may be any of the legend's characters.
+func ParseMap(r io.Reader, legend Legend) ([]Row, error) {
+	for char := range legend {
+		if r, _ := utf8.DecodeRuneInString(char); unicode.IsSpace(r) {
+			return nil, fmt.Errorf("terrain character %q can't be whitespace", char)
+		}
+	}
+
+	grid, err := fileinput.ReadGrid(r, legend.cells())
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, len(grid))
+
+	for i, row := range grid {
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+// TerrainCount is how many cells of a terrain a traversal passed through, and
+// their total cost. The cost of each cell is the embedded Terrain's Cost.
+type TerrainCount struct {
+	Terrain
+	Count     int
+	TotalCost float64
+}
+
+// Traversal breaks down the cells visited going down the map at a slope by
+// terrain, in order of terrain name. Every terrain in the legend is included,
+// even if none of it was visited.
+type Traversal struct {
+	Slope
+	ByTerrain []TerrainCount
+	TotalCost float64
+}
+
+// Traverse goes down the map at a slope, counting the cells of each terrain it
+// visits and their cost.
+func Traverse(rows []Row, s Slope, legend Legend) (Traversal, error) {
+	if err := checkRenderable(rows, []Slope{s}); err != nil {
+		return Traversal{}, err
+	}
+
+	counts := map[string]int{}
+
+	for _, pos := range path(rows, s) {
+		cell := rows[pos.row].CellAt(pos.col)
+
+		if _, ok := legend[cell]; !ok {
+			return Traversal{}, fmt.Errorf("cell %q at row %v isn't in the legend", cell, pos.row+1)
+		}
+
+		counts[cell]++
+	}
+
+	byName := map[string]*TerrainCount{}
+
+	for char, terrain := range legend {
+		tc, ok := byName[terrain.Name]
+		if !ok {
+			tc = &TerrainCount{Terrain: terrain}
+			byName[terrain.Name] = tc
+		}
+
+		tc.Count += counts[char]
+	}
+
+	t := Traversal{Slope: s}
+
+	for _, tc := range byName {
+		tc.TotalCost = float64(tc.Count) * tc.Cost
+		t.ByTerrain = append(t.ByTerrain, *tc)
+	}
+
+	sort.Slice(t.ByTerrain, func(i, j int) bool {
+		return t.ByTerrain[i].Name < t.ByTerrain[j].Name
+	})
+
+	for _, tc := range t.ByTerrain {
+		t.TotalCost += tc.TotalCost
+	}
+
+	return t, nil
+}
@@ -129,13 +129,13 @@
 // Count the number of valid passports - those that have all required fields and valid values. Continue to treat cid as
 // optional. In your batch file, how many passports are valid?
 
-package main
+package day04
 
 import (
+	"aoc2020/solver"
+	_ "embed"
 	"errors"
-	"fmt"
-	"io/ioutil"
-	"log"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
@@ -146,113 +146,53 @@ type Credential map[string]string
 type CredentialField struct {
 	required   bool
 	validation func(string) bool
+	rule       string
 }
 
-var credentialFields = map[string]CredentialField{
-	"byr": {
-		true,
-		func(s string) bool {
-			year, err := yearStringToI(s)
-			return err == nil && year >= 1920 && year <= 2002
-		},
-	},
-	"iyr": {
-		true,
-		func(s string) bool {
-			year, err := yearStringToI(s)
-			return err == nil && year >= 2010 && year <= 2020
-		},
-	},
-	"eyr": {
-		true,
-		func(s string) bool {
-			year, err := yearStringToI(s)
-			return err == nil && year >= 2020 && year <= 2030
-		},
-	},
-	"hgt": {
-		true,
-		func(s string) bool {
-			re := regexp.MustCompile(`^(?P<num>\d+)(?P<unit>cm|in)$`)
-
-			if !re.MatchString(s) {
-				return false
-			}
-
-			match := re.FindStringSubmatch(s)
-
-			captures := make(map[string]string)
+// credentialFields are the puzzle's rules, compiled from DefaultSchema.
+var credentialFields = mustLoadSchema(DefaultSchema)
 
-			for i, val := range match {
-				name := re.SubexpNames()[i]
-				captures[name] = val
-			}
+// DefaultValidator returns the puzzle's rules.
+func DefaultValidator() *Validator {
+	return credentialFields
+}
 
-			num, err := strconv.Atoi(captures["num"])
+func mustLoadSchema(schema string) *Validator {
+	v, err := LoadSchema(strings.NewReader(schema))
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
 
-			if err != nil {
-				return false
-			}
+//go:generate go run ../cmd/genexamples
 
-			if captures["unit"] == "cm" {
-				return num >= 150 && num <= 193
-			}
+//go:embed input.txt
+var input string
 
-			if captures["unit"] == "in" {
-				return num >= 59 && num <= 76
-			}
+//go:embed answers.txt
+var answers string
 
-			return false
-		},
-	},
-	"hcl": {
-		true,
-		func(s string) bool {
-			re := regexp.MustCompile(`^#[0-9a-f]{6}$`)
-			return re.MatchString(s)
-		},
+var Solver = solver.Solver{
+	Day:     4,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInputFile(r)
 	},
-	"ecl": {
-		true,
-		func(s string) bool {
-			re := regexp.MustCompile(`^(amb|blu|brn|gry|grn|hzl|oth)$`)
-			return re.MatchString(s)
-		},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]Credential)), nil
 	},
-	"pid": {
-		true,
-		func(s string) bool {
-			re := regexp.MustCompile(`^[0-9]{9}$`)
-			return re.MatchString(s)
-		},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.([]Credential)), nil
 	},
-	"cid": {
-		false,
-		func(s string) bool {
-			return true
-		},
-	},
-}
-
-func main() {
-	credentials, err := parseInputFile("day04/input.txt")
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	validCountPart1 := part1(credentials)
-	validCountPart2 := part2(credentials)
-
-	fmt.Printf("Valid credentials (part 1): %v\n", validCountPart1)
-	fmt.Printf("Valid credentials (part 2): %v\n", validCountPart2)
 }
 
 func part1(credentials []Credential) int {
 	validCount := 0
 
 	for _, credential := range credentials {
-		if credential.isValidForPart1() {
+		if credentialFields.HasRequiredFields(credential) {
 			validCount++
 		}
 	}
@@ -264,7 +204,7 @@ func part2(credentials []Credential) int {
 	validCount := 0
 
 	for _, credential := range credentials {
-		if credential.isValidForPart2() {
+		if credentialFields.Valid(credential) {
 			validCount++
 		}
 	}
@@ -272,39 +212,34 @@ func part2(credentials []Credential) int {
 	return validCount
 }
 
-func (cred Credential) isValidForPart1() bool {
-	requiredFieldsCount := 0
-	credFieldsCount := 0
-
-	for fieldKey, field := range credentialFields {
-		if !field.required {
+// HasRequiredFields reports whether the credential has every required field,
+// whatever their values.
+func (v *Validator) HasRequiredFields(cred Credential) bool {
+	for _, fieldKey := range v.names {
+		if !v.fields[fieldKey].required {
 			continue
 		}
 
-		requiredFieldsCount += 1
-
-		for credKey, _ := range cred {
-			if credKey == fieldKey {
-				credFieldsCount += 1
-				break
-			}
+		if _, ok := cred[fieldKey]; !ok {
+			return false
 		}
 	}
 
-	return requiredFieldsCount == credFieldsCount
+	return true
 }
 
-func (cred Credential) isValidForPart2() bool {
-	for fieldKey, field := range credentialFields {
-		if !field.required {
+// Valid reports whether the credential has every required field, and every
+// field it has holds a valid value.
+func (v *Validator) Valid(cred Credential) bool {
+	for _, fieldKey := range v.names {
+		field := v.fields[fieldKey]
+		credValue, ok := cred[fieldKey]
+
+		if !ok && !field.required {
 			continue
 		}
 
-		credValue := cred[fieldKey]
-
-		valid := field.validation(credValue)
-
-		if !valid {
+		if !ok || !field.validation(credValue) {
 			return false
 		}
 	}
@@ -312,48 +247,17 @@ func (cred Credential) isValidForPart2() bool {
 	return true
 }
 
-func parseInputFile(fileName string) ([]Credential, error) {
-	data, err := ioutil.ReadFile(fileName)
-
-	if err != nil {
-		return []Credential{}, err
-	}
-
-	records := strings.Split(string(data), "\n\n")
-
-	var credentials []Credential
-
-	for _, record := range records {
-		if record == "" {
-			continue
-		}
-
-		record = strings.TrimSpace(strings.ReplaceAll(record, "\n", " "))
-
-		fieldStrings := strings.Split(record, " ")
-
-		credential := make(Credential)
-
-		for _, fieldString := range fieldStrings {
-			parts := strings.Split(fieldString, ":")
-
-			if len(parts) == 0 {
-				continue
-			}
-
-			credential[parts[0]] = parts[1]
-		}
-
-		credentials = append(credentials, credential)
-	}
-
-	return credentials, nil
+// parseInputFile reads the batch strictly, so that a malformed, duplicate or
+// unknown field is an error.
+func parseInputFile(r io.Reader) ([]Credential, error) {
+	credentials, _, err := ParseBatch(r, credentialFields, true)
+	return credentials, err
 }
 
-func yearStringToI(s string) (int, error) {
-	re := regexp.MustCompile(`^\d{4}$`)
+var fourDigits = regexp.MustCompile(`^\d{4}$`)
 
-	if !re.MatchString(s) {
+func yearStringToI(s string) (int, error) {
+	if !fourDigits.MatchString(s) {
 		return 0, errors.New("must be four digits")
 	}
 
@@ -0,0 +1,494 @@
+package day04
+
+import (
+	"aoc2020/solver/solvertest"
+	"aoc2020/utils/fileinput"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLoadSchema(t *testing.T) {
+	v, err := LoadSchema(strings.NewReader(`{
+		"fields": {
+			"byr": {"required": true, "type": "year", "min": 1900, "max": 1950},
+			"hgt": {"required": true, "type": "measurement", "units": {"mm": {"min": 1500, "max": 1930}}},
+			"hcl": {"required": false, "type": "hex-colour"},
+			"ecl": {"required": true, "type": "enum", "values": ["amb", "vio"]},
+			"cid": {"required": false, "type": "digits", "length": 3}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		cred  Credential
+		want  bool
+		wantR bool
+	}{
+		{"valid", Credential{"byr": "1901", "hgt": "1750mm", "ecl": "vio"}, true, true},
+		{"valid with optional fields", Credential{"byr": "1950", "hgt": "1500mm", "ecl": "amb", "hcl": "#123abc", "cid": "007"}, true, true},
+		{"year out of range", Credential{"byr": "1951", "hgt": "1750mm", "ecl": "vio"}, false, true},
+		{"unknown unit", Credential{"byr": "1901", "hgt": "175cm", "ecl": "vio"}, false, true},
+		{"measurement out of range", Credential{"byr": "1901", "hgt": "1931mm", "ecl": "vio"}, false, true},
+		{"not in enum", Credential{"byr": "1901", "hgt": "1750mm", "ecl": "gry"}, false, true},
+		{"invalid optional field", Credential{"byr": "1901", "hgt": "1750mm", "ecl": "vio", "cid": "1234"}, false, true},
+		{"missing required field", Credential{"byr": "1901", "hgt": "1750mm"}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Valid(tt.cred); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+			if got := v.HasRequiredFields(tt.cred); got != tt.wantR {
+				t.Errorf("HasRequiredFields() = %v, want %v", got, tt.wantR)
+			}
+		})
+	}
+}
+
+func TestLoadSchema_longDigits(t *testing.T) {
+	v, err := LoadSchema(strings.NewReader(`{"fields": {"pid": {"required": true, "type": "digits", "length": 2000}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pid := strings.Repeat("7", 2000); !v.Valid(Credential{"pid": pid}) {
+		t.Error("Valid() = false for 2000 digits, want true")
+	}
+	if pid := strings.Repeat("7", 1999) + "x"; v.Valid(Credential{"pid": pid}) {
+		t.Error("Valid() = true with a letter, want false")
+	}
+}
+
+func TestLoadSchema_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not json", `fields:`},
+		{"no fields", `{"fields": {}}`},
+		{"unknown setting", `{"fields": {"byr": {"required": true, "type": "year", "min": 1, "max": 2, "maxx": 3}}}`},
+		{"no type", `{"fields": {"byr": {"required": true}}}`},
+		{"unknown type", `{"fields": {"byr": {"type": "date"}}}`},
+		{"year without bounds", `{"fields": {"byr": {"type": "year", "min": 1920}}}`},
+		{"year bounds reversed", `{"fields": {"byr": {"type": "year", "min": 2002, "max": 1920}}}`},
+		{"measurement without units", `{"fields": {"hgt": {"type": "measurement"}}}`},
+		{"unit bounds reversed", `{"fields": {"hgt": {"type": "measurement", "units": {"cm": {"min": 193, "max": 150}}}}}`},
+		{"empty enum", `{"fields": {"ecl": {"type": "enum", "values": []}}}`},
+		{"digits without length", `{"fields": {"pid": {"type": "digits"}}}`},
+		{"digits with negative length", `{"fields": {"pid": {"type": "digits", "length": -9}}}`},
+		{"invalid field name", `{"fields": {"p:id": {"type": "any"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadSchema(strings.NewReader(tt.schema)); err == nil {
+				t.Error("LoadSchema() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	cred := Credential{"eyr": "1972", "cid": "100", "hcl": "#18171d", "ecl": "amb", "hgt": "170", "pid": "186cm", "byr": "1926"}
+
+	got := credentialFields.Validate(cred)
+
+	want := Validation{Problems: []FieldProblem{
+		{Field: "eyr", Value: "1972", Rule: "a year from 2020 to 2030"},
+		{Field: "hgt", Value: "170", Rule: "a measurement of 150 to 193cm or 59 to 76in"},
+		{Field: "iyr", Missing: true, Rule: "a year from 2010 to 2020"},
+		{Field: "pid", Value: "186cm", Rule: "9 digits"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	wantString := `invalid eyr "1972", want a year from 2020 to 2030; invalid hgt "170", want a measurement of 150 to 193cm or 59 to 76in; ` +
+		`missing iyr; invalid pid "186cm", want 9 digits`
+	if got.String() != wantString {
+		t.Errorf("Validate().String() = %q, want %q", got.String(), wantString)
+	}
+
+	valid := Credential{"pid": "087499704", "hgt": "74in", "ecl": "grn", "iyr": "2012", "eyr": "2030", "byr": "1980", "hcl": "#623a2f"}
+	if got := credentialFields.Validate(valid); !got.Valid() {
+		t.Errorf("Validate() = %v, want valid", got)
+	}
+}
+
+func TestValidator_ValidateAll(t *testing.T) {
+	creds := []Credential{
+		{"pid": "087499704", "hgt": "74in", "ecl": "grn", "iyr": "2012", "eyr": "2030", "byr": "1980", "hcl": "#623a2f"},
+		{"pid": "08749970", "hgt": "74", "ecl": "grn", "iyr": "2012", "eyr": "2030", "byr": "1980"},
+		{"pid": "087499704", "hgt": "194cm", "ecl": "grn", "iyr": "2012", "eyr": "2030", "byr": "1980"},
+	}
+
+	results, report := credentialFields.ValidateAll(creds)
+
+	if len(results) != 3 || !results[0].Valid() || results[1].Valid() || results[2].Valid() {
+		t.Errorf("ValidateAll() results = %v, want only the first valid", results)
+	}
+
+	want := BatchReport{
+		Total:    3,
+		Valid:    1,
+		Complete: 1,
+		Missing:  map[string]int{"hcl": 2},
+		Invalid:  map[string]int{"hgt": 2, "pid": 1},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("ValidateAll() report = %v, want %v", report, want)
+	}
+
+	if got, want := report.String(), "2 invalid hgt, 2 missing hcl, 1 invalid pid"; got != want {
+		t.Errorf("BatchReport.String() = %q, want %q", got, want)
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(credentialFields))
+	defer srv.Close()
+
+	const valid = "pid:087499704 hgt:74in ecl:grn iyr:2012 eyr:2030 byr:1980\nhcl:#623a2f"
+	const invalid = "eyr:1972 cid:100\nhcl:#18171d ecl:amb hgt:170 pid:186cm iyr:2018 byr:1926"
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantValid   []bool
+		wantSummary string
+	}{
+		{
+			name:        "text",
+			contentType: "text/plain",
+			body:        valid + "\n\n" + invalid + "\n",
+			wantStatus:  http.StatusOK,
+			wantValid:   []bool{true, false},
+			wantSummary: "1 invalid eyr, 1 invalid hgt, 1 invalid pid",
+		},
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `[{"pid": "087499704", "hgt": "74in", "ecl": "grn", "iyr": "2012", "eyr": "2030", "byr": "1980", "hcl": "#623a2f"}, {"byr": "1980"}]`,
+			wantStatus:  http.StatusOK,
+			wantValid:   []bool{true, false},
+			wantSummary: "1 missing ecl, 1 missing eyr, 1 missing hcl, 1 missing hgt, 1 missing iyr, 1 missing pid",
+		},
+		{
+			name:        "malformed text",
+			contentType: "text/plain",
+			body:        "pid:087499704 hgt\n",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "malformed json",
+			contentType: "application/json",
+			body:        `[{"byr": 1980}]`,
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(srv.URL, tt.contentType, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				var e errorJSON
+				if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || e.Error == "" {
+					t.Errorf("error response = %v, %v, want an error message", e, err)
+				}
+				return
+			}
+
+			var got batchJSON
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+
+			var gotValid []bool
+			for _, rec := range got.Records {
+				gotValid = append(gotValid, rec.Valid)
+			}
+			if !reflect.DeepEqual(gotValid, tt.wantValid) {
+				t.Errorf("record validity = %v, want %v", gotValid, tt.wantValid)
+			}
+
+			if got.Summary != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", got.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+const messyBatch = "byr:1937 iyr:2017\nhgt:183cm\n\n" +
+	"iyr:2013 ecl:amb\ncid:350 eyr\n" +
+	"pid:028048884 ecl:brn\n\n" +
+	"hcl:#cfa07d nickname:bob\n"
+
+func TestParseBatch(t *testing.T) {
+	_, _, err := ParseBatch(strings.NewReader(messyBatch), credentialFields, true)
+
+	var parseErr *fileinput.ParseError
+	if !errors.As(err, &parseErr) || !errors.Is(err, ErrMalformedField) {
+		t.Fatalf("ParseBatch(strict) error = %v, want a malformed field ParseError", err)
+	}
+	if parseErr.Record != 2 || parseErr.Line != 5 || parseErr.Text != "cid:350 eyr" {
+		t.Errorf("ParseBatch(strict) error = %v, want record 2, line 5", err)
+	}
+
+	creds, warnings, err := ParseBatch(strings.NewReader(messyBatch), credentialFields, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantCreds := []Credential{
+		{"byr": "1937", "iyr": "2017", "hgt": "183cm"},
+		{"iyr": "2013", "ecl": "amb", "cid": "350", "pid": "028048884"},
+		{"hcl": "#cfa07d", "nickname": "bob"},
+	}
+	if !reflect.DeepEqual(creds, wantCreds) {
+		t.Errorf("ParseBatch(lenient) = %v, want %v", creds, wantCreds)
+	}
+
+	wantWarnings := []struct {
+		err    error
+		record int
+		line   int
+	}{
+		{ErrMalformedField, 2, 5},
+		{ErrDuplicateField, 2, 6},
+		{ErrUnknownField, 3, 8},
+	}
+	if len(warnings) != len(wantWarnings) {
+		t.Fatalf("ParseBatch(lenient) warnings = %v, want %v of them", warnings, len(wantWarnings))
+	}
+	for i, want := range wantWarnings {
+		if w := warnings[i]; !errors.Is(w, want.err) || w.Record != want.record || w.Line != want.line {
+			t.Errorf("warning %v = %v, want %v in record %v, line %v", i, w, want.err, want.record, want.line)
+		}
+	}
+
+	// Without a validator, any field name is allowed
+	if _, warnings, _ := ParseBatch(strings.NewReader("nickname:bob\n"), nil, false); len(warnings) != 0 {
+		t.Errorf("ParseBatch(nil validator) warnings = %v, want none", warnings)
+	}
+}
+
+func TestNewHandler_lenient(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/validate?lenient=1", strings.NewReader(messyBatch))
+	NewHandler(credentialFields).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	var got batchJSON
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Total != 3 || len(got.Warnings) != 3 {
+		t.Errorf("lenient response = %v records, warnings %q, want 3 records and 3 warnings", got.Total, got.Warnings)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(messyBatch))
+	NewHandler(credentialFields).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "line 5") {
+		t.Errorf("strict response = %v %q, want %v mentioning line 5", rec.Code, rec.Body.String(), http.StatusBadRequest)
+	}
+}
+
+func TestNewHandler_lenientParam(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantStatus int
+	}{
+		{"", http.StatusBadRequest},
+		{"?lenient", http.StatusOK},
+		{"?lenient=", http.StatusOK},
+		{"?lenient=1", http.StatusOK},
+		{"?lenient=true", http.StatusOK},
+		{"?lenient=false", http.StatusBadRequest},
+		{"?lenient=0", http.StatusBadRequest},
+		{"?lenient=maybe", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/validate"+tt.query, strings.NewReader(messyBatch))
+			NewHandler(credentialFields).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v: %v", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+// failingReader fails partway through, as when a client disconnects.
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestNewHandler_readError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(credentialFields).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", failingReader{}))
+
+	if rec.Code != http.StatusBadRequest || strings.Contains(rec.Body.String(), "larger than") {
+		t.Errorf("status = %v %q, want %v, not too large", rec.Code, rec.Body.String(), http.StatusBadRequest)
+	}
+}
+
+func TestNewHandler_method(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(credentialFields).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET = %v, Allow %q, want %v, Allow POST", rec.Code, rec.Header().Get("Allow"), http.StatusMethodNotAllowed)
+	}
+}
+
+func TestValidator_ValidateStream(t *testing.T) {
+	var batch bytes.Buffer
+	if err := GenerateBatch(&batch, 5000, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, _, err := ParseBatch(bytes.NewReader(batch.Bytes()), credentialFields, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, want := credentialFields.ValidateAll(creds)
+
+	for _, workers := range []int{1, 4, 0} {
+		got, warnings, err := credentialFields.ValidateStream(bytes.NewReader(batch.Bytes()), workers, true)
+		if err != nil || len(warnings) != 0 {
+			t.Fatalf("ValidateStream(%v workers) = %v, %v, want no problems", workers, warnings, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ValidateStream(%v workers) = %+v, want %+v", workers, got, want)
+		}
+	}
+
+	_, _, wantErr := ParseBatch(strings.NewReader(messyBatch), credentialFields, true)
+	if _, _, err := credentialFields.ValidateStream(strings.NewReader(messyBatch), 4, true); err == nil || err.Error() != wantErr.Error() {
+		t.Errorf("ValidateStream(strict) error = %v, want %v", err, wantErr)
+	}
+
+	_, wantWarnings, _ := ParseBatch(strings.NewReader(messyBatch), credentialFields, false)
+	report, warnings, err := credentialFields.ValidateStream(strings.NewReader(messyBatch), 4, false)
+	if err != nil || report.Total != 3 || !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("ValidateStream(lenient) = %v records, %v, %v, want 3 records, %v", report.Total, warnings, err, wantWarnings)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
+
+// BenchmarkValidateStream measures throughput on a 100,000 record batch held in
+// memory, with a single worker and with one per CPU.
+func BenchmarkValidateStream(b *testing.B) {
+	var batch bytes.Buffer
+	if err := GenerateBatch(&batch, 100000, 1); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, workers := range []int{1, runtime.GOMAXPROCS(0)} {
+		if workers == 1 && runtime.GOMAXPROCS(0) == 1 {
+			continue
+		}
+
+		b.Run(fmt.Sprintf("workers=%v", workers), func(b *testing.B) {
+			benchmarkValidateStream(b, 100000, workers, func() io.Reader {
+				return bytes.NewReader(batch.Bytes())
+			})
+		})
+	}
+}
+
+// BenchmarkValidateStream_10M measures throughput on a 10 million record file,
+// which takes a while to write, so it's skipped with -short.
+func BenchmarkValidateStream_10M(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping 10 million record batch in short mode")
+	}
+
+	path := filepath.Join(b.TempDir(), "passports.txt")
+	f, err := os.Create(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := GenerateBatch(f, 10000000, 1); err != nil {
+		b.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		b.Fatal(err)
+	}
+
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	benchmarkValidateStream(b, 10000000, 0, func() io.Reader {
+		f, err := os.Open(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		files = append(files, f)
+		return f
+	})
+}
+
+func benchmarkValidateStream(b *testing.B, records, workers int, open func() io.Reader) {
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		report, _, err := credentialFields.ValidateStream(open(), workers, true)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if report.Total != records {
+			b.Fatalf("validated %v records, want %v", report.Total, records)
+		}
+	}
+
+	b.ReportMetric(float64(records)*float64(b.N)/b.Elapsed().Seconds(), "records/s")
+}
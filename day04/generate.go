@@ -0,0 +1,78 @@
+package day04
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// GenerateBatch writes n made-up passports in the puzzle's format, for sizing
+// up validation on batches much larger than the puzzle's. The same seed always
+// gives the same batch. Against the puzzle's rules, most of the passports are
+// valid, and the rest are missing a field or have an invalid value.
+func GenerateBatch(w io.Writer, n int, seed int64) error {
+	rnd := rand.New(rand.NewSource(seed))
+	bw := bufio.NewWriter(w)
+
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+
+		fields := []string{
+			fmt.Sprintf("byr:%v", 1920+rnd.Intn(83)),
+			fmt.Sprintf("iyr:%v", 2010+rnd.Intn(11)),
+			fmt.Sprintf("eyr:%v", 2020+rnd.Intn(11)),
+			syntheticHeight(rnd),
+			fmt.Sprintf("hcl:#%06x", rnd.Intn(1<<24)),
+			"ecl:" + eyeColours[rnd.Intn(len(eyeColours))],
+			fmt.Sprintf("pid:%09d", rnd.Intn(1000000000)),
+		}
+		if rnd.Intn(2) == 0 {
+			fields = append(fields, fmt.Sprintf("cid:%v", rnd.Intn(1000)))
+		}
+
+		// A passport in ten has a field missing, and one in five an invalid value
+		switch k := rnd.Intn(10); {
+		case k == 0:
+			j := rnd.Intn(7)
+			fields = append(fields[:j], fields[j+1:]...)
+		case k < 3:
+			j := rnd.Intn(len(invalidFields))
+			fields[j] = invalidFields[j]
+		}
+
+		rnd.Shuffle(len(fields), func(i, j int) {
+			fields[i], fields[j] = fields[j], fields[i]
+		})
+
+		// Split the fields over one to three lines, as the puzzle does
+		for j, field := range fields {
+			switch {
+			case j == 0:
+			case rnd.Intn(3) == 0:
+				bw.WriteString("\n")
+			default:
+				bw.WriteString(" ")
+			}
+			bw.WriteString(field)
+		}
+		bw.WriteString("\n")
+	}
+
+	return bw.Flush()
+}
+
+var eyeColours = []string{"amb", "blu", "brn", "gry", "grn", "hzl", "oth"}
+
+// invalidFields are an invalid value for each required field, in the order
+// GenerateBatch writes them.
+var invalidFields = []string{"byr:2003", "iyr:2021", "eyr:1972", "hgt:190", "hcl:123abc", "ecl:wat", "pid:0123456789"}
+
+func syntheticHeight(rnd *rand.Rand) string {
+	if rnd.Intn(2) == 0 {
+		return fmt.Sprintf("hgt:%vcm", 150+rnd.Intn(44))
+	}
+	return fmt.Sprintf("hgt:%vin", 59+rnd.Intn(18))
+}
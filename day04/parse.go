@@ -0,0 +1,106 @@
+package day04
+
+import (
+	"aoc2020/utils/fileinput"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Problems found while parsing a batch, wrapped in a *fileinput.ParseError
+// giving the record and line they're on.
+var (
+	ErrMalformedField = fileinput.ErrMalformedField
+	ErrDuplicateField = fileinput.ErrDuplicateField
+	ErrUnknownField   = errors.New("unknown field")
+)
+
+// ParseBatch reads a batch of credentials: records separated by blank lines,
+// each a set of key:value fields separated by spaces or newlines. Fields that
+// aren't key:value, fields given twice in a record, and, if v isn't nil, fields
+// that aren't in v, are all problems.
+//
+// In strict mode the first problem is returned as an error. Otherwise each
+// problem is returned as a warning and parsing carries on: a malformed field is
+// dropped, the first value given for a duplicated field is kept, and an
+// unknown field is kept, though it's never validated.
+func ParseBatch(r io.Reader, v *Validator, strict bool) (creds []Credential, warnings []*fileinput.ParseError, err error) {
+	err = eachRecord(r, v, func(cred Credential, problems []*fileinput.ParseError) error {
+		if len(problems) > 0 {
+			if strict {
+				return problems[0]
+			}
+			warnings = append(warnings, problems...)
+		}
+
+		creds = append(creds, cred)
+		return nil
+	})
+
+	return creds, warnings, err
+}
+
+// eachRecord parses each record of a batch in turn, handing it to handler
+// along with any problems found in it. Parsing stops at the first error from
+// handler.
+func eachRecord(r io.Reader, v *Validator, handler func(cred Credential, problems []*fileinput.ParseError) error) error {
+	scanner := fileinput.NewScanner(r, "\n\n")
+
+	for scanner.Scan() {
+		if err := handler(parseRecord(recordAt(scanner), v)); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// record is the text of one record of a batch along with where it was found,
+// so that it can be parsed apart from the scanner that read it.
+type record struct {
+	file   string
+	line   int
+	number int
+	text   string
+}
+
+func recordAt(scanner *fileinput.Scanner) record {
+	return record{scanner.Name(), scanner.Line(), scanner.Record(), scanner.Text()}
+}
+
+// parseRecord parses a record's fields, returning any problems found with
+// them as eachRecord describes.
+func parseRecord(rec record, v *Validator) (Credential, []*fileinput.ParseError) {
+	cred := Credential{}
+	var problems []*fileinput.ParseError
+
+	for _, kv := range fileinput.SplitKeyValues(rec.text) {
+		problem := kv.Err
+
+		if problem == nil {
+			if v != nil && !v.knows(kv.Key) {
+				problem = fmt.Errorf("%w %v", ErrUnknownField, kv.Key)
+			}
+
+			cred[kv.Key] = kv.Value
+		}
+
+		if problem != nil {
+			problems = append(problems, &fileinput.ParseError{
+				File:   rec.file,
+				Line:   rec.line + kv.Line,
+				Record: rec.number,
+				Text:   kv.Text,
+				Err:    problem,
+			})
+		}
+	}
+
+	return cred, problems
+}
+
+// knows reports whether the field is in the validator's schema.
+func (v *Validator) knows(key string) bool {
+	_, ok := v.fields[key]
+	return ok
+}
@@ -0,0 +1,215 @@
+package day04
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema describes each field a credential may have: whether it's required,
+// and what type of value it holds. It's read from JSON, such as:
+//
+//	{
+//	  "fields": {
+//	    "byr": {"required": true, "type": "year", "min": 1920, "max": 2002},
+//	    "hgt": {"required": true, "type": "measurement", "units": {"cm": {"min": 150, "max": 193}}},
+//	    "hcl": {"required": true, "type": "hex-colour"},
+//	    "ecl": {"required": true, "type": "enum", "values": ["amb", "blu"]},
+//	    "pid": {"required": true, "type": "digits", "length": 9},
+//	    "cid": {"required": false, "type": "any"}
+//	  }
+//	}
+//
+// DefaultSchema holds the puzzle's rules in this form.
+type Schema struct {
+	Fields map[string]FieldSchema `json:"fields"`
+}
+
+// FieldSchema describes one field. Which of the other settings apply depends
+// on Type:
+//
+//   - "year": four digits, from Min to Max inclusive
+//   - "measurement": a whole number followed by one of Units, within that
+//     unit's bounds
+//   - "hex-colour": "#" followed by six lowercase hex digits
+//   - "enum": one of Values
+//   - "digits": exactly Length digits
+//   - "any": any value at all
+type FieldSchema struct {
+	Required bool             `json:"required"`
+	Type     string           `json:"type"`
+	Min      *int             `json:"min,omitempty"`
+	Max      *int             `json:"max,omitempty"`
+	Units    map[string]Range `json:"units,omitempty"`
+	Values   []string         `json:"values,omitempty"`
+	Length   int              `json:"length,omitempty"`
+}
+
+// Range is an inclusive range of whole numbers.
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// DefaultSchema is the puzzle's schema, as JSON. It makes a starting point for
+// other rules.
+//
+//go:embed schema.json
+var DefaultSchema string
+
+// Validator holds a schema compiled into a check for each field. Everything is
+// compiled up front, so one Validator can check any number of credentials, from
+// any number of goroutines at once.
+type Validator struct {
+	fields map[string]CredentialField
+	names  []string // field names in order, so problems are always listed alike
+}
+
+// LoadSchema reads a JSON schema and compiles it into a Validator. Settings it
+// doesn't recognise are an error, so that a mistyped rule isn't ignored.
+func LoadSchema(r io.Reader) (*Validator, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var s Schema
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("reading schema: %w", err)
+	}
+
+	return s.Compile()
+}
+
+// Compile checks the schema makes sense and builds a Validator from it.
+func (s Schema) Compile() (*Validator, error) {
+	if len(s.Fields) == 0 {
+		return nil, errors.New("schema has no fields")
+	}
+
+	v := &Validator{fields: map[string]CredentialField{}}
+
+	// Compile in order so that the first bad field reported is always the same
+	var names []string
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, ": \t\n") {
+			return nil, fmt.Errorf("invalid field name %q", name)
+		}
+
+		validation, rule, err := s.Fields[name].compile()
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %w", name, err)
+		}
+
+		v.fields[name] = CredentialField{s.Fields[name].Required, validation, rule}
+		v.names = append(v.names, name)
+	}
+
+	return v, nil
+}
+
+// compile builds the field's check, and a description of the rule it enforces
+// for reporting values that break it.
+func (f FieldSchema) compile() (func(string) bool, string, error) {
+	switch f.Type {
+	case "year":
+		if f.Min == nil || f.Max == nil {
+			return nil, "", errors.New("year needs a min and max")
+		}
+
+		bounds := Range{*f.Min, *f.Max}
+		if bounds.Min > bounds.Max {
+			return nil, "", fmt.Errorf("min %v is more than max %v", bounds.Min, bounds.Max)
+		}
+
+		return func(s string) bool {
+			year, err := yearStringToI(s)
+			return err == nil && year >= bounds.Min && year <= bounds.Max
+		}, fmt.Sprintf("a year from %v to %v", bounds.Min, bounds.Max), nil
+	case "measurement":
+		if len(f.Units) == 0 {
+			return nil, "", errors.New("measurement needs at least one unit")
+		}
+
+		var units, rules []string
+		for unit, bounds := range f.Units {
+			if unit == "" {
+				return nil, "", errors.New("measurement has an empty unit")
+			}
+			if bounds.Min > bounds.Max {
+				return nil, "", fmt.Errorf("unit %v: min %v is more than max %v", unit, bounds.Min, bounds.Max)
+			}
+			units = append(units, regexp.QuoteMeta(unit))
+			rules = append(rules, fmt.Sprintf("%v to %v%v", bounds.Min, bounds.Max, unit))
+		}
+		sort.Strings(units)
+		sort.Strings(rules)
+
+		re := regexp.MustCompile(`^(\d+)(` + strings.Join(units, "|") + `)$`)
+		allUnits := f.Units
+
+		return func(s string) bool {
+			match := re.FindStringSubmatch(s)
+			if match == nil {
+				return false
+			}
+
+			num, err := strconv.Atoi(match[1])
+			bounds := allUnits[match[2]]
+
+			return err == nil && num >= bounds.Min && num <= bounds.Max
+		}, "a measurement of " + strings.Join(rules, " or "), nil
+	case "hex-colour":
+		re := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+		return re.MatchString, "a # followed by six lowercase hex digits", nil
+	case "enum":
+		if len(f.Values) == 0 {
+			return nil, "", errors.New("enum needs at least one value")
+		}
+
+		values := map[string]bool{}
+		for _, value := range f.Values {
+			values[value] = true
+		}
+
+		return func(s string) bool {
+			return values[s]
+		}, "one of " + strings.Join(f.Values, ", "), nil
+	case "digits":
+		if f.Length < 1 {
+			return nil, "", errors.New("digits needs a length of at least 1")
+		}
+
+		length := f.Length
+		return func(s string) bool {
+			return len(s) == length && allDigits(s)
+		}, fmt.Sprintf("%v digits", f.Length), nil
+	case "any":
+		return func(s string) bool {
+			return true
+		}, "anything", nil
+	case "":
+		return nil, "", errors.New("no type given")
+	default:
+		return nil, "", fmt.Errorf("unknown type %q", f.Type)
+	}
+}
+
+// allDigits reports whether s is made up only of the ASCII digits 0-9.
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package day04
+
+import (
+	"aoc2020/utils/fileinput"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// maxRequestBytes caps the size of a batch posted to the handler.
+const maxRequestBytes = 32 << 20
+
+// problemJSON is a FieldProblem as the handler returns it.
+type problemJSON struct {
+	Field   string `json:"field"`
+	Missing bool   `json:"missing"`
+	Value   string `json:"value,omitempty"`
+	Rule    string `json:"rule"`
+}
+
+// recordJSON is one credential's validation as the handler returns it.
+// Record is the credential's 1-based position in the batch.
+type recordJSON struct {
+	Record   int           `json:"record"`
+	Valid    bool          `json:"valid"`
+	Problems []problemJSON `json:"problems"`
+}
+
+// batchJSON is the handler's response to a batch.
+type batchJSON struct {
+	Total    int            `json:"total"`
+	Valid    int            `json:"valid"`
+	Missing  map[string]int `json:"missing"`
+	Invalid  map[string]int `json:"invalid"`
+	Summary  string         `json:"summary"`
+	Warnings []string       `json:"warnings,omitempty"`
+	Records  []recordJSON   `json:"records"`
+}
+
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+// NewHandler returns an HTTP handler that validates a batch of credentials
+// POSTed to it. The batch is either in the puzzle's "key:value" text format, or
+// with a JSON content type, an array of objects mapping each field to its
+// value. The response is JSON giving each credential's validity and problems,
+// in order, along with the tallies for the whole batch.
+//
+// Text batches are parsed strictly, as ParseBatch does, so a malformed,
+// duplicate or unknown field fails the request, unless the lenient query
+// parameter is true, or given without a value, in which case those problems are
+// returned as warnings.
+func NewHandler(v *Validator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, errorJSON{"method not allowed, POST a batch of passports"})
+			return
+		}
+
+		// Read a byte past the limit, so an oversized batch can be told apart
+		// from one that couldn't be read
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBytes+1))
+		switch {
+		case err != nil:
+			writeJSON(w, http.StatusBadRequest, errorJSON{fmt.Sprintf("reading batch: %v", err)})
+			return
+		case len(body) > maxRequestBytes:
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorJSON{fmt.Sprintf("batch is larger than %v bytes", maxRequestBytes)})
+			return
+		}
+
+		lenient, err := lenientParam(r.URL.Query())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorJSON{err.Error()})
+			return
+		}
+
+		creds, warnings, err := decodeBatch(r.Header.Get("Content-Type"), body, v, !lenient)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorJSON{err.Error()})
+			return
+		}
+
+		results, report := v.ValidateAll(creds)
+
+		resp := batchJSON{
+			Total:   report.Total,
+			Valid:   report.Valid,
+			Missing: report.Missing,
+			Invalid: report.Invalid,
+			Summary: report.String(),
+			Records: make([]recordJSON, len(results)),
+		}
+
+		for _, w := range warnings {
+			resp.Warnings = append(resp.Warnings, w.Error())
+		}
+
+		for i, result := range results {
+			rec := recordJSON{Record: i + 1, Valid: result.Valid(), Problems: []problemJSON{}}
+			for _, p := range result.Problems {
+				rec.Problems = append(rec.Problems, problemJSON{p.Field, p.Missing, p.Value, p.Rule})
+			}
+			resp.Records[i] = rec
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// lenientParam reads the lenient query parameter as a boolean. Giving it
+// without a value, as in "?lenient", turns it on.
+func lenientParam(query url.Values) (bool, error) {
+	values, ok := query["lenient"]
+	if !ok {
+		return false, nil
+	}
+	if values[0] == "" {
+		return true, nil
+	}
+
+	lenient, err := strconv.ParseBool(values[0])
+	if err != nil {
+		return false, fmt.Errorf("invalid lenient parameter %q, want true or false", values[0])
+	}
+	return lenient, nil
+}
+
+// decodeBatch reads a batch of credentials as JSON or text, depending on the
+// content type.
+func decodeBatch(contentType string, body []byte, v *Validator, strict bool) ([]Credential, []*fileinput.ParseError, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType != "application/json" {
+		return ParseBatch(bytes.NewReader(body), v, strict)
+	}
+
+	var creds []Credential
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if err := dec.Decode(&creds); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON batch, want an array of objects of strings: %w", err)
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, nil, errors.New("invalid JSON batch: unexpected data after the array")
+	}
+
+	return creds, nil, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,130 @@
+package day04
+
+import (
+	"aoc2020/utils/fileinput"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// recordsPerJob is how many records are handed to a worker at a time, so the
+// cost of passing them over a channel is shared across many records.
+const recordsPerJob = 512
+
+// jobResult is what a worker found in one job's records.
+type jobResult struct {
+	first    int // number of the job's first record, for putting problems in order
+	report   BatchReport
+	problems []*fileinput.ParseError
+}
+
+// ValidateStream reads a batch as ParseBatch does and validates it, parsing and
+// validating the records across a pool of workers goroutines as the batch is
+// read. Only the tallies are kept, not the credentials, so a batch of any size
+// can be checked. If workers is less than 1, one is started for each CPU.
+//
+// In strict mode the first problem in the batch is returned as an error.
+// Otherwise the problems are returned as warnings, in the order they appear in
+// the batch.
+func (v *Validator) ValidateStream(r io.Reader, workers int, strict bool) (BatchReport, []*fileinput.ParseError, error) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan []record, workers)
+	results := make(chan jobResult, workers)
+
+	// stopped is set once a strict problem is found, so no more is read
+	var stopped int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results <- v.validateJob(job, strict, &stopped)
+			}
+		}()
+	}
+
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+
+		scanner := fileinput.NewScanner(r, "\n\n")
+		job := make([]record, 0, recordsPerJob)
+
+		for atomic.LoadInt32(&stopped) == 0 && scanner.Scan() {
+			job = append(job, recordAt(scanner))
+			if len(job) == recordsPerJob {
+				jobs <- job
+				job = make([]record, 0, recordsPerJob)
+			}
+		}
+		if len(job) > 0 {
+			jobs <- job
+		}
+
+		readErr <- scanner.Err()
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	report := newBatchReport()
+	var found []jobResult
+	for result := range results {
+		report.merge(result.report)
+		if len(result.problems) > 0 {
+			found = append(found, result)
+		}
+	}
+
+	if err := <-readErr; err != nil {
+		return BatchReport{}, nil, err
+	}
+
+	// Every job before the one with the earliest problem was read and finished,
+	// so after sorting, the first problem is the first in the batch
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].first < found[j].first
+	})
+
+	var warnings []*fileinput.ParseError
+	for _, result := range found {
+		warnings = append(warnings, result.problems...)
+	}
+
+	if strict && len(warnings) > 0 {
+		return BatchReport{}, nil, warnings[0]
+	}
+
+	return report, warnings, nil
+}
+
+// validateJob parses and validates each of a job's records. In strict mode it
+// stops at the first problem and sets stopped.
+func (v *Validator) validateJob(job []record, strict bool, stopped *int32) jobResult {
+	result := jobResult{first: job[0].number, report: newBatchReport()}
+
+	for _, rec := range job {
+		cred, problems := parseRecord(rec, v)
+
+		if len(problems) > 0 {
+			result.problems = append(result.problems, problems...)
+			if strict {
+				atomic.StoreInt32(stopped, 1)
+				break
+			}
+		}
+
+		result.report.add(v.Validate(cred))
+	}
+
+	return result
+}
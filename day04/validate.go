@@ -0,0 +1,164 @@
+package day04
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldProblem is a credential field that's missing, or whose value breaks the
+// field's rule.
+type FieldProblem struct {
+	Field   string
+	Missing bool
+	Value   string
+	Rule    string
+}
+
+func (p FieldProblem) String() string {
+	if p.Missing {
+		return fmt.Sprintf("missing %v", p.Field)
+	}
+	return fmt.Sprintf("invalid %v %q, want %v", p.Field, p.Value, p.Rule)
+}
+
+// Validation is every problem found with a credential, in order of field name.
+type Validation struct {
+	Problems []FieldProblem
+}
+
+// Valid reports whether no problems were found.
+func (v Validation) Valid() bool {
+	return len(v.Problems) == 0
+}
+
+func (v Validation) String() string {
+	if v.Valid() {
+		return "valid"
+	}
+
+	problems := make([]string, len(v.Problems))
+	for i, p := range v.Problems {
+		problems[i] = p.String()
+	}
+	return strings.Join(problems, "; ")
+}
+
+// Validate checks every field of the credential, rather than stopping at the
+// first problem as Valid does.
+func (v *Validator) Validate(cred Credential) Validation {
+	var result Validation
+
+	for _, fieldKey := range v.names {
+		field := v.fields[fieldKey]
+		credValue, ok := cred[fieldKey]
+
+		switch {
+		case !ok && field.required:
+			result.Problems = append(result.Problems, FieldProblem{Field: fieldKey, Missing: true, Rule: field.rule})
+		case ok && !field.validation(credValue):
+			result.Problems = append(result.Problems, FieldProblem{Field: fieldKey, Value: credValue, Rule: field.rule})
+		}
+	}
+
+	return result
+}
+
+// BatchReport tallies the problems found across a batch of credentials: how
+// many credentials were missing each field, and how many had an invalid value
+// for it. Complete counts the credentials with every required field, whatever
+// their values.
+type BatchReport struct {
+	Total    int
+	Valid    int
+	Complete int
+	Missing  map[string]int
+	Invalid  map[string]int
+}
+
+func newBatchReport() BatchReport {
+	return BatchReport{Missing: map[string]int{}, Invalid: map[string]int{}}
+}
+
+// ValidateAll validates each of the credentials, returning their results in
+// the same order along with a report for the whole batch.
+func (v *Validator) ValidateAll(creds []Credential) ([]Validation, BatchReport) {
+	results := make([]Validation, len(creds))
+	report := newBatchReport()
+
+	for i, cred := range creds {
+		results[i] = v.Validate(cred)
+		report.add(results[i])
+	}
+
+	return results, report
+}
+
+func (r *BatchReport) add(result Validation) {
+	r.Total++
+	if result.Valid() {
+		r.Valid++
+	}
+
+	complete := true
+	for _, p := range result.Problems {
+		if p.Missing {
+			r.Missing[p.Field]++
+			complete = false
+		} else {
+			r.Invalid[p.Field]++
+		}
+	}
+
+	if complete {
+		r.Complete++
+	}
+}
+
+// merge adds the tallies of another report to r.
+func (r *BatchReport) merge(other BatchReport) {
+	r.Total += other.Total
+	r.Valid += other.Valid
+	r.Complete += other.Complete
+
+	for field, count := range other.Missing {
+		r.Missing[field] += count
+	}
+	for field, count := range other.Invalid {
+		r.Invalid[field] += count
+	}
+}
+
+// String summarises the problems, most common first, such as
+// "312 invalid hgt, 41 missing pid".
+func (r BatchReport) String() string {
+	type tally struct {
+		count int
+		text  string
+	}
+
+	var tallies []tally
+	for field, count := range r.Invalid {
+		tallies = append(tallies, tally{count, fmt.Sprintf("%v invalid %v", count, field)})
+	}
+	for field, count := range r.Missing {
+		tallies = append(tallies, tally{count, fmt.Sprintf("%v missing %v", count, field)})
+	}
+
+	if len(tallies) == 0 {
+		return "no problems"
+	}
+
+	sort.Slice(tallies, func(i, j int) bool {
+		if tallies[i].count != tallies[j].count {
+			return tallies[i].count > tallies[j].count
+		}
+		return tallies[i].text < tallies[j].text
+	})
+
+	texts := make([]string, len(tallies))
+	for i, t := range tallies {
+		texts[i] = t.text
+	}
+	return strings.Join(texts, ", ")
+}
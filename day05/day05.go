@@ -63,12 +63,16 @@
 //
 // What is the ID of your seat?
 
-package main
+package day05
 
 import (
+	"aoc2020/solver"
 	"aoc2020/utils/fileinput"
+	_ "embed"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"regexp"
 	"sort"
 )
 
@@ -79,22 +83,45 @@ var (
 	totalCols = 8
 )
 
-func main() {
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     5,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInput(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]BoardingPass)), nil
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.([]BoardingPass))
+	},
+}
+
+var boardingPassRegexp = regexp.MustCompile(`^[FB]{7}[LR]{3}$`)
+
+func parseInput(r io.Reader) ([]BoardingPass, error) {
 	var boardingPasses []BoardingPass
 
-	err := fileinput.LoadThen("day05/input.txt", "\n", func(s string) {
-		boardingPasses = append(boardingPasses, BoardingPass(s))
-	})
+	err := fileinput.ReadEach(r, "\n", func(s string) error {
+		if !boardingPassRegexp.MatchString(s) {
+			return errors.New("expected 7 F/B characters followed by 3 L/R characters")
+		}
 
-	if err != nil {
-		log.Fatal(err)
-	}
+		boardingPasses = append(boardingPasses, BoardingPass(s))
 
-	highestPassID := part1(boardingPasses)
-	mySeatID := part2(boardingPasses)
+		return nil
+	})
 
-	fmt.Printf("Highest pass ID (part 1): %v\n", highestPassID)
-	fmt.Printf("My seat ID (part 2): %v\n", mySeatID)
+	return boardingPasses, err
 }
 
 func part1(passes []BoardingPass) int {
@@ -111,24 +138,28 @@ func part1(passes []BoardingPass) int {
 	return highestPassID
 }
 
-func part2(passes []BoardingPass) int {
+func part2(passes []BoardingPass) (int, error) {
 	var seatIDs []int
 	for _, pass := range passes {
 		seatIDs = append(seatIDs, pass.seatDetails()[2])
 	}
 	sort.Ints(seatIDs)
 
+	if len(seatIDs) == 0 {
+		return 0, fmt.Errorf("%w: no boarding passes", solver.ErrNoSolution)
+	}
+
 	for i, prevID := 1, seatIDs[0]; i < len(seatIDs)-1; i++ {
 		thisID := seatIDs[i]
 
 		if thisID-prevID > 1 {
-			return thisID - 1
+			return thisID - 1, nil
 		}
 
 		prevID = thisID
 	}
 
-	return -1
+	return 0, fmt.Errorf("%w: could not find an empty seat", solver.ErrNoSolution)
 }
 
 func (bp BoardingPass) seatDetails() [3]int {
@@ -0,0 +1,18 @@
+package day05
+
+import (
+	"aoc2020/solver/solvertest"
+	"testing"
+)
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
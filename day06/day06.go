@@ -91,12 +91,13 @@
 // For each group, count the number of questions to which everyone answered
 // "yes". What is the sum of those counts?
 
-package main
+package day06
 
 import (
+	"aoc2020/solver"
 	"aoc2020/utils/fileinput"
-	"fmt"
-	"log"
+	_ "embed"
+	"io"
 	"strings"
 )
 
@@ -104,20 +105,40 @@ type Declaration string
 
 type GroupDeclaration []Declaration
 
-func main() {
-	var groupDeclarations []GroupDeclaration
-	err := fileinput.LoadThen("day06/input.txt", "\n\n", func(s string) {
-		groupDeclarations = append(groupDeclarations, newGroupDeclaration(s))
-	})
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     6,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInput(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]GroupDeclaration)), nil
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.([]GroupDeclaration)), nil
+	},
+}
+
+func parseInput(r io.Reader) ([]GroupDeclaration, error) {
+	groups, err := fileinput.ReadGroups(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	totalAnswers := part1(groupDeclarations)
-	totalCommonAnswers := part2(groupDeclarations)
-
-	fmt.Printf("Total answers (part 1): %v\n", totalAnswers)
-	fmt.Printf("Total answers (part 2): %v\n", totalCommonAnswers)
+	var groupDeclarations []GroupDeclaration
+	for _, group := range groups {
+		groupDeclarations = append(groupDeclarations, newGroupDeclaration(group))
+	}
+	return groupDeclarations, nil
 }
 
 func part1(groupDecs []GroupDeclaration) int {
@@ -136,9 +157,9 @@ func part2(groupDecs []GroupDeclaration) int {
 	return total
 }
 
-func newGroupDeclaration(record string) GroupDeclaration {
+func newGroupDeclaration(lines []string) GroupDeclaration {
 	var decs []Declaration
-	for _, str := range strings.Split(record, "\n") {
+	for _, str := range lines {
 		decs = append(decs, Declaration(str))
 	}
 	return decs
@@ -78,12 +78,14 @@
 //
 // How many individual bags are required inside your single shiny gold bag?
 
-package main
+package day07
 
 import (
+	"aoc2020/solver"
 	"aoc2020/utils/fileinput"
+	_ "embed"
 	"fmt"
-	"log"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
@@ -115,13 +117,42 @@ type edge struct {
 	weight int
 }
 
-func main() {
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     7,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInput(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.(*graph))
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.(*graph))
+	},
+}
+
+func parseInput(r io.Reader) (*graph, error) {
 	var rules []rule
-	err := fileinput.LoadThen("day07/input.txt", "\n", func(s string) {
-		rules = append(rules, ruleFromString(s))
+	err := fileinput.ReadEach(r, "\n", func(s string) error {
+		rule, err := ruleFromString(s)
+		if err != nil {
+			return err
+		}
+
+		rules = append(rules, rule)
+		return nil
 	})
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	g := newGraph()
@@ -131,19 +162,27 @@ func main() {
 		}
 	}
 
-	numberContainingShinyGold := part1(g)
-	numberContainedByShinyGold := part2(g)
-
-	fmt.Printf("Number of bags that can contain a shiny gold bag (part 1): %v\n", numberContainingShinyGold)
-	fmt.Printf("Number of bags contained by shiny gold bag (part 2): %v\n", numberContainedByShinyGold)
+	return g, nil
 }
 
-func part1(g *graph) int {
-	return len(g.allAscendants(g.nodes["shiny gold"]))
+var errNoShinyGold = fmt.Errorf("%w: no rules mention shiny gold bags", solver.ErrNoSolution)
+
+func part1(g *graph) (int, error) {
+	n, ok := g.nodes["shiny gold"]
+	if !ok {
+		return 0, errNoShinyGold
+	}
+
+	return len(g.allAscendants(n)), nil
 }
 
-func part2(g *graph) int {
-	return g.combinedChildrenWeight(g.nodes["shiny gold"])
+func part2(g *graph) (int, error) {
+	n, ok := g.nodes["shiny gold"]
+	if !ok {
+		return 0, errNoShinyGold
+	}
+
+	return g.combinedChildrenWeight(n), nil
 }
 
 func newGraph() *graph {
@@ -205,7 +244,7 @@ func (g *graph) combinedChildrenWeight(n *node) int {
 	return weight
 }
 
-func ruleFromString(s string) rule {
+func ruleFromString(s string) (rule, error) {
 	// Example: "striped fuchsia bags contain 3 dotted green bags, 2 plaid maroon bags."
 
 	// Strip spurious detail: the word "bag"/"bags" and trailing "."
@@ -213,7 +252,7 @@ func ruleFromString(s string) rule {
 
 	parts := strings.Split(s, " contain ")
 	if len(parts) != 2 {
-		log.Fatalf("expected only 2 parts in %v", parts)
+		return rule{}, fmt.Errorf("expected only 2 parts in %v", parts)
 	}
 
 	var c []contained
@@ -224,12 +263,12 @@ func ruleFromString(s string) rule {
 
 		parts := strings.Split(s, " ")
 		if len(parts) != 3 {
-			log.Fatalf("expected only 3 parts in %v", parts)
+			return rule{}, fmt.Errorf("expected only 3 parts in %v", parts)
 		}
 
 		qty, err := strconv.Atoi(parts[0])
 		if err != nil {
-			log.Fatal(err)
+			return rule{}, err
 		}
 
 		descriptor := strings.Join(parts[1:], " ")
@@ -243,5 +282,5 @@ func ruleFromString(s string) rule {
 	return rule{
 		descriptor: parts[0],
 		contained:  c,
-	}
+	}, nil
 }
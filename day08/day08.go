@@ -119,13 +119,15 @@
 // (to nop) or nop (to jmp). What is the value of the accumulator after the
 // program terminates?
 
-package main
+package day08
 
 import (
+	"aoc2020/solver"
 	"aoc2020/utils/fileinput"
+	_ "embed"
 	"fmt"
 	"github.com/jinzhu/copier"
-	"log"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -135,28 +137,49 @@ type instruction struct {
 	arg int
 }
 
-func main() {
-	var ins []*instruction
-	err := fileinput.LoadThen("day08/input.txt", "\n", func(s string) {
-		ins = append(ins, instructionFromString(s))
-	})
-	if err != nil {
-		log.Fatal(err)
-	}
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
 
-	accumulatorBeforeRepeatedInstruction := part1(ins)
-	accumulatorAfterFixedInstructions := part2(ins)
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     8,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInput(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]*instruction))
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.([]*instruction))
+	},
+}
+
+func parseInput(r io.Reader) ([]*instruction, error) {
+	var ins []*instruction
+	err := fileinput.ReadEach(r, "\n", func(s string) error {
+		in, err := instructionFromString(s)
+		if err != nil {
+			return err
+		}
 
-	fmt.Printf("Accumulator before repeated instruction (part 1): %v\n", accumulatorBeforeRepeatedInstruction)
-	fmt.Printf("Accumulator after fixed instructions (part 2): %v\n", accumulatorAfterFixedInstructions)
+		ins = append(ins, in)
+		return nil
+	})
+	return ins, err
 }
 
-func part1(ins []*instruction) int {
-	acc, _ := executeInstructions(ins)
-	return acc
+func part1(ins []*instruction) (int, error) {
+	acc, _, err := executeInstructions(ins)
+	return acc, err
 }
 
-func part2(ins []*instruction) int {
+func part2(ins []*instruction) (int, error) {
 	for idx, in := range ins {
 		if in.op == "jmp" || in.op == "nop" {
 			newIns := make([]*instruction, len(ins))
@@ -165,7 +188,7 @@ func part2(ins []*instruction) int {
 			newIn := &instruction{}
 			err := copier.Copy(newIn, in)
 			if err != nil {
-				log.Fatalf("failed to copy instruction %v", in)
+				return 0, fmt.Errorf("failed to copy instruction %v: %v", in, err)
 			}
 
 			if in.op == "jmp" {
@@ -177,18 +200,21 @@ func part2(ins []*instruction) int {
 			//goland:noinspection GoNilness
 			newIns[idx] = newIn
 
-			acc, completed := executeInstructions(newIns)
+			acc, completed, err := executeInstructions(newIns)
+			if err != nil {
+				return 0, err
+			}
 
 			if completed {
-				return acc
+				return acc, nil
 			}
 		}
 	}
 
-	panic("could not fix instructions")
+	return 0, fmt.Errorf("%w: could not fix instructions", solver.ErrNoSolution)
 }
 
-func executeInstructions(ins []*instruction) (finalAcc int, completed bool) {
+func executeInstructions(ins []*instruction) (finalAcc int, completed bool, err error) {
 	acc := 0
 	called := map[int]int{}
 	i := 0
@@ -197,46 +223,59 @@ func executeInstructions(ins []*instruction) (finalAcc int, completed bool) {
 		called[i]++
 
 		if called[i] > 1 {
-			return acc, false
+			return acc, false, nil
 		}
 
 		if i >= len(ins) {
-			return acc, true
+			return acc, true, nil
+		}
+
+		if i < 0 {
+			return acc, false, fmt.Errorf("jumped to instruction %v, before the start of the program", i)
 		}
 
-		nextAcc, jump := execute(ins[i], acc)
+		nextAcc, jump, err := execute(ins[i], acc)
+		if err != nil {
+			return acc, false, err
+		}
 
 		acc = nextAcc
 		i = i + jump
 	}
 }
 
-func execute(i *instruction, acc int) (nextAcc int, jump int) {
+func execute(i *instruction, acc int) (nextAcc int, jump int, err error) {
 	switch i.op {
 	case "acc":
-		return acc + i.arg, 1
+		return acc + i.arg, 1, nil
 	case "jmp":
-		return acc, i.arg
+		return acc, i.arg, nil
 	case "nop":
-		return acc, 1
+		return acc, 1, nil
 	default:
-		panic("unknown instruction")
+		return acc, 0, fmt.Errorf("unknown instruction %v", i.op)
 	}
 }
 
-func instructionFromString(s string) *instruction {
+func instructionFromString(s string) (*instruction, error) {
 	parts := strings.Split(s, " ")
 	if len(parts) != 2 {
-		log.Fatalf("expected 2 parts in %v", parts)
+		return nil, fmt.Errorf("expected 2 parts in %v", parts)
+	}
+
+	switch parts[0] {
+	case "acc", "jmp", "nop":
+	default:
+		return nil, fmt.Errorf("unknown operation %v", parts[0])
 	}
 
 	arg, err := strconv.Atoi(parts[1])
 	if err != nil {
-		log.Fatalf("integer argument expected, received %v", parts[1])
+		return nil, fmt.Errorf("integer argument expected, received %v", parts[1])
 	}
 
 	return &instruction{
 		op:  parts[0],
 		arg: arg,
-	}
+	}, nil
 }
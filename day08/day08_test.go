@@ -0,0 +1,46 @@
+package day08
+
+import (
+	"aoc2020/solver"
+	"aoc2020/solver/solvertest"
+	"aoc2020/utils/fileinput"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
+
+func Test_parseInput_error(t *testing.T) {
+	_, err := parseInput(strings.NewReader("nop +0\n\njmp two\nacc +1\n"))
+
+	var parseErr *fileinput.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("parseInput() error = %v, want a *fileinput.ParseError", err)
+	}
+
+	if parseErr.Line != 3 || parseErr.Record != 2 || parseErr.Text != "jmp two" {
+		t.Errorf("parseInput() error at line %v, record %v (%q), want line 3, record 2 (\"jmp two\")", parseErr.Line, parseErr.Record, parseErr.Text)
+	}
+}
+
+func Test_part2_noSolution(t *testing.T) {
+	ins, err := parseInput(strings.NewReader("jmp +0\njmp -1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := part2(ins); !errors.Is(err, solver.ErrNoSolution) {
+		t.Errorf("part2() error = %v, want ErrNoSolution", err)
+	}
+}
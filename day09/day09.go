@@ -104,58 +104,73 @@
 //
 // What is the encryption weakness in your XMAS-encrypted list of numbers?
 
-package main
+package day09
 
 import (
+	"aoc2020/solver"
 	"aoc2020/utils/fileinput"
+	_ "embed"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
-	"strconv"
 )
 
 var addendsLength = 25
 
-func main() {
-	var nums []int
-	err := fileinput.LoadThen("day09/input.txt", "\n", func(s string) {
-		num, err := strconv.Atoi(s)
-		if err != nil {
-			panic(err)
-		}
+//go:generate go run ../cmd/genexamples
 
-		nums = append(nums, num)
-	})
-	if err != nil {
-		panic(err)
-	}
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
 
-	numberWithoutAddends := part1(nums)                                         // Answer: 18272118
-	sumOfSmallestLargesInContiguousAddends := part2(nums, numberWithoutAddends) // Answer: 2186361
+var Solver = solver.Solver{
+	Day:     9,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return fileinput.ReadInts(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]int))
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		nums := input.([]int)
 
-	fmt.Printf("First number without addends (part 1): %v\n", numberWithoutAddends)
-	fmt.Printf("Sum of smallest and largest in range of contiguous addends (part 2): %v\n", sumOfSmallestLargesInContiguousAddends)
+		sum, err := part1(nums)
+		if err != nil {
+			return nil, err
+		}
+
+		return part2(nums, sum)
+	},
 }
 
-func part1(nums []int) int {
+func part1(nums []int) (int, error) {
 	for i := addendsLength; i < len(nums); i++ {
 		num := nums[i]
 		addends := nums[i-addendsLength : i]
 
 		_, err := addendsForSum(addends, num)
 		if err != nil {
-			return num
+			return num, nil
 		}
 	}
 
-	panic("could not find number missing addends")
+	return 0, fmt.Errorf("%w: could not find number missing addends", solver.ErrNoSolution)
 }
 
-func part2(nums []int, sum int) int {
-	addends := contiguousAddendsForSum(nums, sum)
+func part2(nums []int, sum int) (int, error) {
+	addends, err := contiguousAddendsForSum(nums, sum)
+	if err != nil {
+		return 0, err
+	}
+
 	sort.Ints(addends)
 
-	return addends[0] + addends[len(addends)-1]
+	return addends[0] + addends[len(addends)-1], nil
 }
 
 func addendsForSum(nums []int, sum int) ([2]int, error) {
@@ -174,7 +189,7 @@ func addendsForSum(nums []int, sum int) ([2]int, error) {
 	return [2]int{}, errors.New("no two working addends")
 }
 
-func contiguousAddendsForSum(nums []int, targetSum int) []int {
+func contiguousAddendsForSum(nums []int, targetSum int) ([]int, error) {
 	for i, startNum := range nums {
 		if startNum >= targetSum {
 			break
@@ -192,10 +207,10 @@ func contiguousAddendsForSum(nums []int, targetSum int) []int {
 			addends = append(addends, nextNum)
 
 			if sum == targetSum {
-				return addends
+				return addends, nil
 			}
 		}
 	}
 
-	panic("could not find contiguous range of addends")
+	return nil, fmt.Errorf("%w: could not find contiguous range of addends", solver.ErrNoSolution)
 }
@@ -0,0 +1,72 @@
+package day09
+
+import (
+	"aoc2020/solver"
+	"aoc2020/solver/solvertest"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestContiguousAddendsForSum(t *testing.T) {
+	nums := []int{
+		35,
+		20,
+		15,
+		25,
+		47,
+		40,
+		62,
+		55,
+		65,
+		95,
+		102,
+		117,
+		150,
+		182,
+		127,
+		219,
+		299,
+		277,
+		309,
+		576,
+	}
+
+	targetSum := 127
+
+	want := []int{15, 25, 47, 40}
+	result, err := contiguousAddendsForSum(nums, targetSum)
+	if err != nil {
+		t.Fatalf("contiguousAddendsForSum(...) error = %v", err)
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("contiguousAddendsForSum(...) = %v; want %v", result, want)
+	}
+
+	if _, err := contiguousAddendsForSum(nums, 1); !errors.Is(err, solver.ErrNoSolution) {
+		t.Errorf("contiguousAddendsForSum(...) error = %v; want ErrNoSolution", err)
+	}
+}
+
+// useExamplePreamble switches to the 5-number preamble used by the puzzle's
+// example, restoring the real one once the test is done.
+func useExamplePreamble(t *testing.T) {
+	original := addendsLength
+	addendsLength = 5
+	t.Cleanup(func() {
+		addendsLength = original
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
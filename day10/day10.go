@@ -180,58 +180,66 @@
 // What is the total number of distinct ways you can arrange the adapters to
 // connect the charging outlet to your device?
 
-package main
+package day10
 
 import (
+	"aoc2020/solver"
 	"aoc2020/utils/fileinput"
+	_ "embed"
 	"fmt"
-	"log"
+	"io"
 	"sort"
-	"strconv"
 )
 
-func main() {
-	var adapters []int
-	err := fileinput.LoadThen("day10/input.txt", "\n", func(s string) {
-		num, err := strconv.Atoi(s)
-		if err != nil {
-			panic(err)
-		}
+//go:generate go run ../cmd/genexamples
 
-		adapters = append(adapters, num)
-	})
-	if err != nil {
-		panic(err)
-	}
+//go:embed input.txt
+var input string
 
-	multipliedDiffs := part1(adapters)     // 2080
-	numberOfCobinations := part2(adapters) // 6908379398144
+//go:embed answers.txt
+var answers string
 
-	fmt.Printf("Number of 1-jolt diffs * number of 3-jolt diffs (part 1): %v\n", multipliedDiffs)
-	fmt.Printf("Number of possible combinations (part 2): %v\n", numberOfCobinations)
+var Solver = solver.Solver{
+	Day:     10,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return fileinput.ReadInts(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.([]int))
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.([]int))
+	},
 }
 
-func part1(adapters []int) int {
-	diffs := joltageDifferences(adapters)
+func part1(adapters []int) (int, error) {
+	diffs, err := joltageDifferences(adapters)
+	if err != nil {
+		return 0, err
+	}
 
-	return diffs[1] * diffs[3]
+	return diffs[1] * diffs[3], nil
 }
 
-func part2(adapters []int) int {
+func part2(adapters []int) (int, error) {
 	return arrangementCount(adapters)
 }
 
-func joltageDifferences(adapters []int) map[int]int {
-	sort.Ints(adapters)
+func joltageDifferences(adapters []int) (map[int]int, error) {
+	// Sort a copy, leaving the caller's adapters as they were
+	sorted := append([]int(nil), adapters...)
+	sort.Ints(sorted)
 
 	diffs := map[int]int{}
 	joltage := 0
 
-	for _, adapter := range adapters {
+	for _, adapter := range sorted {
 		diff := adapter - joltage
 
 		if diff > 3 {
-			panic("No suitable adapter available")
+			return nil, fmt.Errorf("%w: no suitable adapter available for %v jolts", solver.ErrNoSolution, joltage)
 		}
 
 		diffs[diff]++
@@ -242,7 +250,7 @@ func joltageDifferences(adapters []int) map[int]int {
 	diffs[3]++
 	joltage += 3
 
-	return diffs
+	return diffs, nil
 }
 
 var combinationsForGroupSize = map[int]int{
@@ -253,10 +261,11 @@ var combinationsForGroupSize = map[int]int{
 	5: 7,
 }
 
-func arrangementCount(adapters []int) int {
+func arrangementCount(adapters []int) (int, error) {
 	// Sort the adapters, and include the source joltage (0), which is needed to
-	// properly determining all combinations
-	adapters = append(adapters, 0)
+	// properly determining all combinations. This works on a copy, as appending
+	// could otherwise write into the caller's backing array.
+	adapters = append(append([]int(nil), adapters...), 0)
 	sort.Ints(adapters)
 
 	// Calculate differences between each adapter in the sorted list
@@ -275,7 +284,7 @@ func arrangementCount(adapters []int) int {
 	size := 0
 	for i, delta := range deltas {
 		if delta != 1 && delta != 3 {
-			log.Panicf("unknown delta %v", delta)
+			return 0, fmt.Errorf("%w: unknown delta %v", solver.ErrNoSolution, delta)
 		}
 
 		if delta == 1 {
@@ -298,11 +307,11 @@ func arrangementCount(adapters []int) int {
 	for _, size := range branchGroupSizes {
 		combination, ok := combinationsForGroupSize[size]
 		if !ok {
-			log.Panicf("No combination for group size %v", size)
+			return 0, fmt.Errorf("%w: no combination for group size %v", solver.ErrNoSolution, size)
 		}
 
 		arrangements *= combination
 	}
 
-	return arrangements
+	return arrangements, nil
 }
@@ -1,6 +1,9 @@
-package main
+package day10
 
 import (
+	"aoc2020/solver"
+	"aoc2020/solver/solvertest"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -67,7 +70,11 @@ func Test_joltageDifferences(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := joltageDifferences(tt.args.adapters); !reflect.DeepEqual(got, tt.want) {
+			got, err := joltageDifferences(tt.args.adapters)
+			if err != nil {
+				t.Fatalf("joltageDifferences() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("joltageDifferences() = %v, want %v", got, tt.want)
 			}
 		})
@@ -88,9 +95,31 @@ func Test_arrangementCount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := arrangementCount(tt.args.adapters); got != tt.want {
+			got, err := arrangementCount(tt.args.adapters)
+			if err != nil {
+				t.Fatalf("arrangementCount() error = %v", err)
+			}
+			if got != tt.want {
 				t.Errorf("arrangementCount() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_joltageDifferences_noSolution(t *testing.T) {
+	if _, err := joltageDifferences([]int{1, 2, 6}); !errors.Is(err, solver.ErrNoSolution) {
+		t.Errorf("joltageDifferences() error = %v, want ErrNoSolution", err)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
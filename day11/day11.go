@@ -236,40 +236,49 @@
 // Given the new visibility method and the rule change for occupied seats
 // becoming empty, once equilibrium is reached, how many seats end up occupied?
 
-package main
+package day11
 
 import (
+	"aoc2020/solver"
 	"aoc2020/utils/fileinput"
-	"fmt"
-	"strings"
+	_ "embed"
+	"io"
 )
 
 type room struct {
 	cells [][]string
 }
 
-func main() {
-	var positions [][]string
-	err := fileinput.LoadThen("day11/input.txt", "\n", func(s string) {
-		var row []string
-		for _, rowStr := range strings.Split(s, "\n") {
-			for _, c := range rowStr {
-				row = append(row, string(c))
-			}
-		}
-		positions = append(positions, row)
-	})
+//go:generate go run ../cmd/genexamples
+
+//go:embed input.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+var Solver = solver.Solver{
+	Day:     11,
+	Input:   input,
+	Answers: answers,
+	Parse: func(r io.Reader) (interface{}, error) {
+		return parseInput(r)
+	},
+	Part1: func(input interface{}) (interface{}, error) {
+		return part1(input.(*room)), nil
+	},
+	Part2: func(input interface{}) (interface{}, error) {
+		return part2(input.(*room)), nil
+	},
+}
+
+func parseInput(r io.Reader) (*room, error) {
+	positions, err := fileinput.ReadGrid(r, ".L#")
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	r := newRoom(positions)
-
-	part1Visible := part1(r) // 2283
-	part2Visible := part2(r) // 2054
-
-	fmt.Printf("Visible (part 1): %v\n", part1Visible)
-	fmt.Printf("Visible (part 2): %v\n", part2Visible)
+	return newRoom(positions), nil
 }
 
 func part1(r *room) int {
@@ -353,19 +362,24 @@ func (r *room) occupiedCount() int {
 }
 
 func (r *room) neighbours(x, y int) []string {
-	checks := map[int][]int{
-		x - 1: {y - 1, y, y + 1},
-		x:     {y - 1, y + 1},
-		x + 1: {y - 1, y, y + 1},
+	// Rows are checked in order (above, same, below) so the neighbours come back
+	// in a stable order
+	checks := []struct {
+		x  int
+		ys []int
+	}{
+		{x - 1, []int{y - 1, y, y + 1}},
+		{x, []int{y - 1, y + 1}},
+		{x + 1, []int{y - 1, y, y + 1}},
 	}
 
 	neighbours := []string{}
 
-	for xCheck, yChecks := range checks {
-		if xCheck >= 0 && xCheck < len(r.cells) {
-			row := r.cells[xCheck]
+	for _, check := range checks {
+		if check.x >= 0 && check.x < len(r.cells) {
+			row := r.cells[check.x]
 
-			for _, yCheck := range yChecks {
+			for _, yCheck := range check.ys {
 				if yCheck >= 0 && yCheck < len(row) {
 					neighbours = append(neighbours, row[yCheck])
 				}
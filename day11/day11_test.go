@@ -1,6 +1,7 @@
-package main
+package day11
 
 import (
+	"aoc2020/solver/solvertest"
 	"fmt"
 	"reflect"
 	"strings"
@@ -112,3 +113,15 @@ func cellsToStr(cells [][]string) string {
 
 	return out
 }
+
+func BenchmarkParse(b *testing.B) {
+	solvertest.BenchmarkParse(b, Solver)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.BenchmarkPart(b, Solver, 2)
+}
@@ -1,5 +1,5 @@
 module aoc2020
 
-go 1.15
+go 1.16
 
 require github.com/jinzhu/copier v0.1.0
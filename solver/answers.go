@@ -0,0 +1,65 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Answers holds the known correct answers for a puzzle input, keyed by day and
+// part.
+type Answers map[PartKey]string
+
+type PartKey struct {
+	Day  int
+	Part int
+}
+
+// ParseAnswers reads an answers manifest. Each line holds a day, a part and the
+// expected answer, separated by whitespace:
+//
+//	# day part answer
+//	1 1 211899
+//	1 2 275765682
+//
+// Blank lines and lines starting with # are ignored.
+func ParseAnswers(r io.Reader) (Answers, error) {
+	answers := Answers{}
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %v: expected day, part and answer, got %q", lineNum, line)
+		}
+
+		day, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %v: invalid day %q", lineNum, fields[0])
+		}
+
+		part, err := strconv.Atoi(fields[1])
+		if err != nil || part < 1 || part > 2 {
+			return nil, fmt.Errorf("line %v: invalid part %q", lineNum, fields[1])
+		}
+
+		key := PartKey{day, part}
+		if _, ok := answers[key]; ok {
+			return nil, fmt.Errorf("line %v: duplicate answer for day %v part %v", lineNum, day, part)
+		}
+
+		answers[key] = fields[2]
+	}
+
+	return answers, scanner.Err()
+}
@@ -0,0 +1,36 @@
+package solver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAnswers(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Answers
+		wantErr bool
+	}{
+		{
+			name:  "answers with comments and blank lines",
+			input: "# day part answer\n1 1 211899\n\n1 2 275765682\n",
+			want:  Answers{{1, 1}: "211899", {1, 2}: "275765682"},
+		},
+		{name: "missing answer", input: "1 1\n", wantErr: true},
+		{name: "invalid part", input: "1 3 42\n", wantErr: true},
+		{name: "duplicate", input: "1 1 42\n1 1 43\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAnswers(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAnswers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAnswers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
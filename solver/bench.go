@@ -0,0 +1,122 @@
+package solver
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// Stats summarises repeated runs of one stage of a solver: parsing the input,
+// or solving one of the parts.
+type Stats struct {
+	Day          int           `json:"day"`
+	Stage        string        `json:"stage"`
+	Runs         int           `json:"runs"`
+	Min          time.Duration `json:"min_ns"`
+	Median       time.Duration `json:"median_ns"`
+	P95          time.Duration `json:"p95_ns"`
+	AllocsPerRun uint64        `json:"allocs_per_run"`
+	BytesPerRun  uint64        `json:"bytes_per_run"`
+}
+
+// Benchmark parses the input and solves both parts the given number of times,
+// returning stats for the parse, part1 and part2 stages in that order. Each
+// run parses the input afresh so parts that modify it don't affect later runs.
+func (s Solver) Benchmark(input []byte, runs int) ([]Stats, error) {
+	if runs < 1 {
+		return nil, fmt.Errorf("runs must be at least 1, got %v", runs)
+	}
+
+	stages := []string{"parse", "part1", "part2"}
+	samples := make([][]sample, len(stages))
+
+	for i := 0; i < runs; i++ {
+		var parsed interface{}
+		var err error
+
+		samples[0] = append(samples[0], measure(func() {
+			parsed, err = s.Parse(bytes.NewReader(input))
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("day %v: %v", s.Day, err)
+		}
+
+		for j, part := range []func(interface{}) (interface{}, error){s.Part1, s.Part2} {
+			samples[j+1] = append(samples[j+1], measure(func() {
+				_, err = part(parsed)
+			}))
+			if err != nil {
+				return nil, fmt.Errorf("day %v %v: %v", s.Day, stages[j+1], err)
+			}
+		}
+	}
+
+	var stats []Stats
+	for i, stage := range stages {
+		stats = append(stats, summarise(s.Day, stage, samples[i]))
+	}
+
+	return stats, nil
+}
+
+type sample struct {
+	elapsed time.Duration
+	allocs  uint64
+	bytes   uint64
+}
+
+func measure(f func()) sample {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	f()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return sample{
+		elapsed: elapsed,
+		allocs:  after.Mallocs - before.Mallocs,
+		bytes:   after.TotalAlloc - before.TotalAlloc,
+	}
+}
+
+func summarise(day int, stage string, samples []sample) Stats {
+	durations := make([]time.Duration, len(samples))
+	var allocs, bytes uint64
+
+	for i, s := range samples {
+		durations[i] = s.elapsed
+		allocs += s.allocs
+		bytes += s.bytes
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	runs := uint64(len(samples))
+
+	return Stats{
+		Day:          day,
+		Stage:        stage,
+		Runs:         len(samples),
+		Min:          durations[0],
+		Median:       percentile(durations, 50),
+		P95:          percentile(durations, 95),
+		AllocsPerRun: allocs / runs,
+		BytesPerRun:  bytes / runs,
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,32 @@
+package solver
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_percentile(t *testing.T) {
+	var durations []time.Duration
+	for i := 1; i <= 20; i++ {
+		durations = append(durations, time.Duration(i))
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      int
+		want   time.Duration
+	}{
+		{name: "median", sorted: durations, p: 50, want: 10},
+		{name: "p95", sorted: durations, p: 95, want: 19},
+		{name: "p100", sorted: durations, p: 100, want: 20},
+		{name: "single run", sorted: durations[:1], p: 95, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
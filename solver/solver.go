@@ -0,0 +1,186 @@
+package solver
+
+import (
+	"aoc2020/utils/fileinput"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Solver holds everything needed to solve a single day's puzzle. Parse turns
+// the puzzle input into whatever structure the day works with, which is then
+// handed to each of the parts. Input is the day's own puzzle input, embedded
+// in the binary, and is used whenever no other input is given. Answers is the
+// answers manifest for that input.
+type Solver struct {
+	Day     int
+	Input   string
+	Answers string
+	Parse   func(r io.Reader) (interface{}, error)
+	Part1   func(input interface{}) (interface{}, error)
+	Part2   func(input interface{}) (interface{}, error)
+}
+
+// ErrNoSolution is wrapped by the errors a part returns when its input parsed
+// fine but has no answer, such as a day 9 list with no invalid number. Input
+// that couldn't be parsed is reported as a *fileinput.ParseError instead.
+var ErrNoSolution = errors.New("no solution")
+
+// Result is the outcome of solving one part of a day's puzzle. InputHash is
+// the hex-encoded SHA-256 of the input it was solved against.
+type Result struct {
+	Day       int
+	Part      int
+	Answer    interface{}
+	Err       error
+	Elapsed   time.Duration
+	InputHash string
+}
+
+// Solve parses the input and runs both parts against it, timing each. If the
+// input can't be parsed, the error is returned and also carried by each part's
+// result; errors from the parts themselves are only reported in their results.
+func (s Solver) Solve(r io.Reader) ([]Result, error) {
+	hash := sha256.New()
+	tee := io.TeeReader(r, hash)
+
+	// Keep the input's name, if it has one, for parse errors to refer to
+	if n, ok := r.(interface{ Name() string }); ok {
+		tee = fileinput.Named(tee, n.Name())
+	}
+
+	input, parseErr := s.Parse(tee)
+
+	// Parsers may stop before the end of the input, so hash whatever is left
+	if _, err := io.Copy(hash, r); err != nil && parseErr == nil {
+		parseErr = err
+	}
+
+	inputHash := hex.EncodeToString(hash.Sum(nil))
+
+	var results []Result
+	for i, part := range []func(interface{}) (interface{}, error){s.Part1, s.Part2} {
+		result := Result{Day: s.Day, Part: i + 1, InputHash: inputHash}
+
+		if parseErr != nil {
+			result.Err = parseErr
+		} else {
+			start := time.Now()
+			result.Answer, result.Err = part(input)
+			result.Elapsed = time.Since(start)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, parseErr
+}
+
+// Open returns a reader for the puzzle input at path. An empty path means the
+// embedded default input, and "-" means stdin.
+func (s Solver) Open(path string) (io.ReadCloser, error) {
+	switch path {
+	case "":
+		return embeddedInput{strings.NewReader(s.Input), s.Day}, nil
+	case "-":
+		return os.Stdin, nil
+	default:
+		return os.Open(path)
+	}
+}
+
+// embeddedInput reads a day's embedded input, naming it for parse errors.
+type embeddedInput struct {
+	*strings.Reader
+	day int
+}
+
+func (e embeddedInput) Name() string {
+	return fmt.Sprintf("day%02d/input.txt (embedded)", e.day)
+}
+
+func (e embeddedInput) Close() error {
+	return nil
+}
+
+// Registry maps day numbers to their solvers.
+type Registry map[int]Solver
+
+func NewRegistry(solvers ...Solver) Registry {
+	r := Registry{}
+	for _, s := range solvers {
+		r[s.Day] = s
+	}
+	return r
+}
+
+// Days returns the registered day numbers in ascending order.
+func (r Registry) Days() []int {
+	days := make([]int, 0, len(r))
+	for day := range r {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Select returns the solvers matching a day specifier: a single day ("8"), an
+// inclusive range ("1-11"), or "all". Days within a range that have no solver
+// are skipped, but asking for a single missing day is an error.
+func (r Registry) Select(spec string) ([]Solver, error) {
+	if spec == "all" {
+		var solvers []Solver
+		for _, day := range r.Days() {
+			solvers = append(solvers, r[day])
+		}
+		return solvers, nil
+	}
+
+	if parts := strings.SplitN(spec, "-", 2); len(parts) == 2 {
+		from, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid day range %q", spec)
+		}
+
+		to, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid day range %q", spec)
+		}
+
+		if from > to {
+			return nil, fmt.Errorf("invalid day range %q: %v is after %v", spec, from, to)
+		}
+
+		var solvers []Solver
+		for _, day := range r.Days() {
+			if day >= from && day <= to {
+				solvers = append(solvers, r[day])
+			}
+		}
+
+		if len(solvers) == 0 {
+			return nil, fmt.Errorf("no solvers for days %v", spec)
+		}
+
+		return solvers, nil
+	}
+
+	day, err := strconv.Atoi(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day %q", spec)
+	}
+
+	s, ok := r[day]
+	if !ok {
+		return nil, fmt.Errorf("no solver for day %v", day)
+	}
+
+	return []Solver{s}, nil
+}
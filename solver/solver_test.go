@@ -0,0 +1,42 @@
+package solver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistry_Select(t *testing.T) {
+	r := NewRegistry(Solver{Day: 1}, Solver{Day: 2}, Solver{Day: 3}, Solver{Day: 5})
+
+	tests := []struct {
+		name    string
+		spec    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "single day", spec: "2", want: []int{2}},
+		{name: "range", spec: "2-5", want: []int{2, 3, 5}},
+		{name: "all", spec: "all", want: []int{1, 2, 3, 5}},
+		{name: "missing day", spec: "4", wantErr: true},
+		{name: "empty range", spec: "6-9", wantErr: true},
+		{name: "backwards range", spec: "5-2", wantErr: true},
+		{name: "not a day", spec: "eight", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solvers, err := r.Select(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []int
+			for _, s := range solvers {
+				got = append(got, s.Day)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
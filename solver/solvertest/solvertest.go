@@ -0,0 +1,100 @@
+// Package solvertest runs puzzle examples against a day's solver.
+package solvertest
+
+import (
+	"aoc2020/solver"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Example is a worked example from a puzzle description. Part1 and Part2 hold
+// the expected answers; either may be empty when the puzzle doesn't give one.
+// Setup, if set, runs before the example is solved.
+type Example struct {
+	Name  string
+	Input string
+	Part1 string
+	Part2 string
+	Setup func(t *testing.T)
+}
+
+// RunExamples solves each example as a subtest and checks its answers.
+func RunExamples(t *testing.T, s solver.Solver, examples []Example) {
+	t.Helper()
+
+	for _, ex := range examples {
+		ex := ex
+		t.Run(ex.Name, func(t *testing.T) {
+			if ex.Setup != nil {
+				ex.Setup(t)
+			}
+
+			input, err := s.Parse(strings.NewReader(ex.Input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			parts := []struct {
+				solve func(interface{}) (interface{}, error)
+				want  string
+			}{
+				{s.Part1, ex.Part1},
+				{s.Part2, ex.Part2},
+			}
+
+			for i, p := range parts {
+				if p.want == "" {
+					continue
+				}
+
+				got, err := p.solve(input)
+				if err != nil {
+					t.Errorf("part%v() error = %v", i+1, err)
+					continue
+				}
+
+				if fmt.Sprint(got) != p.want {
+					t.Errorf("part%v() = %v, want %v", i+1, got, p.want)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkParse benchmarks parsing the solver's embedded input.
+func BenchmarkParse(b *testing.B, s solver.Solver) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Parse(strings.NewReader(s.Input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPart benchmarks solving part 1 or 2 of the solver's embedded input.
+// The input is parsed afresh for each run, as solver.Benchmark does, so parts
+// that modify it don't affect later runs, but only the solving is measured.
+func BenchmarkPart(b *testing.B, s solver.Solver, part int) {
+	solve := s.Part1
+	if part == 2 {
+		solve = s.Part2
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		input, err := s.Parse(strings.NewReader(s.Input))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		if _, err := solve(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package fileinput
+
+import "fmt"
+
+// ParseError reports a record of the input that couldn't be parsed.
+type ParseError struct {
+	File   string // name of the input, if known
+	Line   int    // 1-based line the record starts on, if known
+	Record int    // 1-based position of the record in the input
+	Text   string // the record that couldn't be parsed
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("%v:%v: record %v (%q): %v", e.File, e.Line, e.Record, e.Text, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("line %v: record %v (%q): %v", e.Line, e.Record, e.Text, e.Err)
+	default:
+		return fmt.Sprintf("record %v (%q): %v", e.Record, e.Text, e.Err)
+	}
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -1,26 +1,73 @@
 package fileinput
 
 import (
-	"io/ioutil"
-	"strings"
+	"io"
+	"os"
 )
 
 func LoadThen(fileName string, separator string, handler func(s string)) error {
-	data, err := ioutil.ReadFile(fileName)
+	f, err := os.Open(fileName)
 
 	if err != nil {
 		return err
 	}
 
-	records := strings.Split(string(data), separator)
+	defer f.Close()
 
-	for _, record := range records {
-		if record == "" {
-			continue
-		}
+	return ReadThen(f, separator, handler)
+}
+
+// ReadThen is LoadThen for input coming from somewhere other than a named file,
+// such as stdin or an embedded default.
+func ReadThen(r io.Reader, separator string, handler func(s string)) error {
+	return ReadEach(r, separator, func(s string) error {
+		handler(s)
+		return nil
+	})
+}
+
+// LoadEach is like LoadThen, but the handler can reject a record by returning
+// an error. Loading stops at the first rejected record, and its error is
+// returned as a *ParseError pointing to the record's place in the file.
+func LoadEach(fileName string, separator string, handler func(s string) error) error {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
 
-		handler(strings.TrimSpace(record))
+	return ReadEach(f, separator, handler)
+}
+
+// ReadEach is LoadEach for input coming from a reader. If the reader has a
+// Name method, as *os.File does, errors are reported against that name.
+//
+// Records are streamed through a Scanner, so separator must be either "\n"
+// or "\n\n".
+func ReadEach(r io.Reader, separator string, handler func(s string) error) error {
+	scanner := NewScanner(r, separator)
+
+	for scanner.Scan() {
+		if err := handler(scanner.Text()); err != nil {
+			return scanner.ParseError(err)
+		}
 	}
 
-	return nil
+	return scanner.Err()
+}
+
+// Named attaches a name to a reader, for ReadEach to report errors against.
+func Named(r io.Reader, name string) io.Reader {
+	return namedReader{r, name}
+}
+
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (nr namedReader) Name() string {
+	return nr.name
 }
@@ -0,0 +1,67 @@
+package fileinput
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadEach(t *testing.T) {
+	errBad := errors.New("bad record")
+
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		wantErr   *ParseError
+		want      []string
+	}{
+		{
+			name:      "lines",
+			input:     "one\n\ntwo  \nthree\n",
+			separator: "\n",
+			want:      []string{"one", "two", "three"},
+		},
+		{
+			name:      "bad line after a blank line",
+			input:     "one\n\nbad\nthree\n",
+			separator: "\n",
+			wantErr:   &ParseError{File: "input.txt", Line: 3, Record: 2, Text: "bad", Err: errBad},
+		},
+		{
+			name:      "bad group",
+			input:     "a\nb\n\nc\n\n\nd\nbad\n",
+			separator: "\n\n",
+			wantErr:   &ParseError{File: "input.txt", Line: 7, Record: 3, Text: "d\nbad", Err: errBad},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := ReadEach(Named(strings.NewReader(tt.input), "input.txt"), tt.separator, func(s string) error {
+				if strings.Contains(s, "bad") {
+					return errBad
+				}
+				got = append(got, s)
+				return nil
+			})
+
+			if tt.wantErr != nil {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) || !reflect.DeepEqual(parseErr, tt.wantErr) {
+					t.Fatalf("ReadEach() error = %#v, want %#v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ReadEach() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadEach() records = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
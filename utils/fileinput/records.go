@@ -0,0 +1,182 @@
+package fileinput
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ReadInts reads one integer per line.
+func ReadInts(r io.Reader) ([]int, error) {
+	var nums []int
+
+	err := ReadEach(r, "\n", func(s string) error {
+		num, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+
+		nums = append(nums, num)
+		return nil
+	})
+
+	return nums, err
+}
+
+// ReadInt64s reads one 64-bit integer per line.
+func ReadInt64s(r io.Reader) ([]int64, error) {
+	var nums []int64
+
+	err := ReadEach(r, "\n", func(s string) error {
+		num, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		nums = append(nums, num)
+		return nil
+	})
+
+	return nums, err
+}
+
+// ReadBigInts reads one integer of any size per line.
+func ReadBigInts(r io.Reader) ([]*big.Int, error) {
+	var nums []*big.Int
+
+	err := ReadEach(r, "\n", func(s string) error {
+		num, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return fmt.Errorf("invalid integer %q", s)
+		}
+
+		nums = append(nums, num)
+		return nil
+	})
+
+	return nums, err
+}
+
+// ReadGrid reads a grid of characters, one row per line, with each character
+// as a cell. Every row must be the same width, and if cells is non-empty,
+// only the characters it contains are allowed.
+func ReadGrid(r io.Reader, cells string) ([][]string, error) {
+	var grid [][]string
+
+	err := ReadEach(r, "\n", func(s string) error {
+		var row []string
+
+		for _, c := range s {
+			if cells != "" && !strings.ContainsRune(cells, c) {
+				return fmt.Errorf("unexpected cell %q, expected one of %q", c, cells)
+			}
+
+			row = append(row, string(c))
+		}
+
+		if len(grid) > 0 && len(row) != len(grid[0]) {
+			return fmt.Errorf("row is %v cells wide, expected %v", len(row), len(grid[0]))
+		}
+
+		grid = append(grid, row)
+		return nil
+	})
+
+	return grid, err
+}
+
+// ReadGroups reads records separated by blank lines, returning the lines of
+// each record.
+func ReadGroups(r io.Reader) ([][]string, error) {
+	var groups [][]string
+
+	err := ReadEach(r, "\n\n", func(s string) error {
+		groups = append(groups, strings.Split(s, "\n"))
+		return nil
+	})
+
+	return groups, err
+}
+
+// Problems with a key:value field, as reported by SplitKeyValues.
+var (
+	ErrMalformedField = errors.New("malformed field")
+	ErrDuplicateField = errors.New("duplicate field")
+)
+
+// KeyValue is one field of a key:value record. Line is the 0-based line of
+// the record it's on, and Text that line. Err says why the field is malformed
+// or a duplicate, if it is.
+type KeyValue struct {
+	Key   string
+	Value string
+	Line  int
+	Text  string
+	Err   error
+}
+
+// SplitKeyValues splits a record into its key:value fields, which are
+// separated by spaces or newlines. A field without a key or value wraps
+// ErrMalformedField, and a key already given earlier in the record wraps
+// ErrDuplicateField; both are still returned, so that the caller can decide
+// what to do with them.
+func SplitKeyValues(record string) []KeyValue {
+	var fields []KeyValue
+	seen := map[string]string{}
+
+	for i, line := range strings.Split(record, "\n") {
+		for _, field := range strings.Fields(line) {
+			kv := KeyValue{Line: i, Text: line}
+
+			parts := strings.SplitN(field, ":", 2)
+
+			switch {
+			case len(parts) != 2 || parts[0] == "" || parts[1] == "":
+				kv.Err = fmt.Errorf("%w %q, want key:value", ErrMalformedField, field)
+			default:
+				kv.Key, kv.Value = parts[0], parts[1]
+
+				if first, ok := seen[kv.Key]; ok {
+					kv.Err = fmt.Errorf("%w %v, already given as %q", ErrDuplicateField, kv.Key, first)
+				} else {
+					seen[kv.Key] = kv.Value
+				}
+			}
+
+			fields = append(fields, kv)
+		}
+	}
+
+	return fields
+}
+
+// ReadKeyValues reads records separated by blank lines, where each record is
+// a set of key:value fields separated by spaces or newlines. A malformed or
+// duplicated field is reported against the line it's on.
+func ReadKeyValues(r io.Reader) ([]map[string]string, error) {
+	var records []map[string]string
+
+	scanner := NewScanner(r, "\n\n")
+
+	for scanner.Scan() {
+		record := map[string]string{}
+
+		for _, kv := range SplitKeyValues(scanner.Text()) {
+			if kv.Err != nil {
+				err := scanner.ParseError(kv.Err)
+				err.Line += kv.Line
+				err.Text = kv.Text
+				return nil, err
+			}
+
+			record[kv.Key] = kv.Value
+		}
+
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
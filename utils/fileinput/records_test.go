@@ -0,0 +1,134 @@
+package fileinput
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadInts(t *testing.T) {
+	got, err := ReadInts(strings.NewReader("1721\n979\n\n-366\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{1721, 979, -366}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadInts() = %v, want %v", got, want)
+	}
+
+	_, err = ReadInts(strings.NewReader("1721\n979\n\n36x6\n"))
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 4 {
+		t.Errorf("ReadInts() error = %v, want a ParseError on line 4", err)
+	}
+}
+
+func TestReadBigInts(t *testing.T) {
+	got, err := ReadBigInts(strings.NewReader("1721\n\n-99999999999999999999999\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"1721", "-99999999999999999999999"}
+	if len(got) != len(want) {
+		t.Fatalf("ReadBigInts() = %v, want %v", got, want)
+	}
+	for i, num := range got {
+		if num.String() != want[i] {
+			t.Errorf("ReadBigInts()[%v] = %v, want %v", i, num, want[i])
+		}
+	}
+
+	_, err = ReadBigInts(strings.NewReader("1721\n1e6\n"))
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("ReadBigInts() error = %v, want a ParseError on line 2", err)
+	}
+}
+
+func TestReadGrid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		cells    string
+		want     [][]string
+		wantLine int
+	}{
+		{name: "grid", input: "L.#\n#.L\n", cells: ".L#", want: [][]string{{"L", ".", "#"}, {"#", ".", "L"}}},
+		{name: "any cells", input: "ab\ncd\n", want: [][]string{{"a", "b"}, {"c", "d"}}},
+		{name: "unexpected cell", input: "L.#\n#xL\n", cells: ".L#", wantLine: 2},
+		{name: "ragged", input: "L.#\n#.L\n#.\n", cells: ".L#", wantLine: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadGrid(strings.NewReader(tt.input), tt.cells)
+
+			if tt.wantLine > 0 {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) || parseErr.Line != tt.wantLine {
+					t.Fatalf("ReadGrid() error = %v, want a ParseError on line %v", err, tt.wantLine)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadGrid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadGroups(t *testing.T) {
+	got, err := ReadGroups(strings.NewReader("abc\n\na\nb\n\n\nab\nac\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{"abc"}, {"a", "b"}, {"ab", "ac"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadGroups() = %v, want %v", got, want)
+	}
+}
+
+func TestReadKeyValues(t *testing.T) {
+	got, err := ReadKeyValues(strings.NewReader("ecl:gry pid:860033327\nbyr:1937\n\nhcl:#cfa07d\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]string{
+		{"ecl": "gry", "pid": "860033327", "byr": "1937"},
+		{"hcl": "#cfa07d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadKeyValues() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  error
+		text  string
+	}{
+		{"malformed", "ecl:gry\n\npid:860033327\nbyr 1937\n", ErrMalformedField, "byr 1937"},
+		{"empty value", "ecl:gry\n\npid:860033327\nbyr:\n", ErrMalformedField, "byr:"},
+		{"duplicate key", "ecl:gry\n\npid:860033327\nbyr:1937 pid:1\n", ErrDuplicateField, "byr:1937 pid:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadKeyValues(strings.NewReader(tt.input))
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || !errors.Is(err, tt.want) || parseErr.Line != 4 || parseErr.Record != 2 || parseErr.Text != tt.text {
+				t.Errorf("ReadKeyValues() error = %#v, want %v on line 4 of record 2", err, tt.want)
+			}
+		})
+	}
+}
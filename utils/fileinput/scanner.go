@@ -0,0 +1,125 @@
+package fileinput
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxLineLength is the longest line a Scanner will read before failing with
+// bufio.ErrTooLong.
+const MaxLineLength = 1024 * 1024
+
+// Scanner reads the records of an input one at a time, holding no more than the
+// current record in memory. With a "\n" separator each non-blank line is a
+// record; with "\n\n" each run of non-blank lines is a record, with lines
+// joined by "\n". Blank lines are those holding nothing but whitespace, and
+// whitespace is trimmed from either end of each line.
+type Scanner struct {
+	lines  *bufio.Scanner
+	groups bool
+	name   string
+	err    error
+
+	lineNum int
+	line    int
+	record  int
+	text    string
+}
+
+func NewScanner(r io.Reader, separator string) *Scanner {
+	s := &Scanner{
+		lines:  bufio.NewScanner(r),
+		groups: separator == "\n\n",
+	}
+
+	s.lines.Buffer(make([]byte, 0, 64*1024), MaxLineLength)
+
+	if n, ok := r.(interface{ Name() string }); ok {
+		s.name = n.Name()
+	}
+
+	if separator != "\n" && separator != "\n\n" {
+		s.err = fmt.Errorf("unsupported separator %q", separator)
+	}
+
+	return s
+}
+
+// Scan advances to the next record, returning false at the end of the input or
+// on a read error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	var group []string
+
+	for s.lines.Scan() {
+		s.lineNum++
+		line := strings.TrimSpace(s.lines.Text())
+
+		if line == "" {
+			if len(group) > 0 {
+				break
+			}
+			continue
+		}
+
+		if len(group) == 0 {
+			s.line = s.lineNum
+		}
+
+		group = append(group, line)
+
+		if !s.groups {
+			break
+		}
+	}
+
+	if err := s.lines.Err(); err != nil {
+		s.err = fmt.Errorf("line %v: %w", s.lineNum+1, err)
+		return false
+	}
+
+	if len(group) == 0 {
+		return false
+	}
+
+	s.record++
+	s.text = strings.Join(group, "\n")
+
+	return true
+}
+
+// Text returns the current record.
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Line returns the 1-based line the current record starts on.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Record returns the 1-based position of the current record in the input.
+func (s *Scanner) Record() int {
+	return s.record
+}
+
+// Name returns the name of the input, if it has one.
+func (s *Scanner) Name() string {
+	return s.name
+}
+
+// Err returns the first error encountered reading the input, if any.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// ParseError wraps err, a problem with the current record, with the record's
+// position in the input.
+func (s *Scanner) ParseError(err error) *ParseError {
+	return &ParseError{File: s.name, Line: s.line, Record: s.record, Text: s.text, Err: err}
+}
@@ -0,0 +1,78 @@
+package fileinput
+
+import (
+	"bufio"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+	type record struct {
+		Line int
+		Text string
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		want      []record
+	}{
+		{
+			name:      "lines",
+			input:     "one\r\n  \ntwo \n\nthree",
+			separator: "\n",
+			want:      []record{{1, "one"}, {3, "two"}, {5, "three"}},
+		},
+		{
+			name:      "groups separated by whitespace-only lines",
+			input:     "\nab\nac \n \t\nb\n\n\n\nc\nd\n",
+			separator: "\n\n",
+			want:      []record{{2, "ab\nac"}, {5, "b"}, {9, "c\nd"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScanner(strings.NewReader(tt.input), tt.separator)
+
+			var got []record
+			for s.Scan() {
+				got = append(got, record{s.Line(), s.Text()})
+
+				if s.Record() != len(got) {
+					t.Errorf("Record() = %v, want %v", s.Record(), len(got))
+				}
+			}
+
+			if err := s.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_longLine(t *testing.T) {
+	input := "short\n" + strings.Repeat("x", MaxLineLength+1) + "\n"
+	s := NewScanner(strings.NewReader(input), "\n")
+
+	for s.Scan() {
+	}
+
+	if err := s.Err(); !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("Err() = %v, want %v", err, bufio.ErrTooLong)
+	}
+}
+
+func TestScanner_unsupportedSeparator(t *testing.T) {
+	s := NewScanner(strings.NewReader("a,b"), ",")
+
+	if s.Scan() || s.Err() == nil {
+		t.Error("expected an error for an unsupported separator")
+	}
+}
@@ -2,12 +2,104 @@ package str
 
 import (
 	"errors"
+	"unicode"
 )
 
+var errOutOfBounds = errors.New("index exceeds string bounds")
+
+// CharAt returns the byte at index as a string. It only suits ASCII text; use
+// RuneAt or GraphemeAt for anything else.
 func CharAt(str string, index int) (string, error) {
-	if index >= len(str) {
-		return "", errors.New("index exceeds string bounds")
+	if index < 0 || index >= len(str) {
+		return "", errOutOfBounds
 	}
 
 	return string(str[index]), nil
 }
+
+// RuneAt returns the index'th rune of str, counting runes rather than bytes.
+func RuneAt(str string, index int) (rune, error) {
+	if index < 0 {
+		return 0, errOutOfBounds
+	}
+
+	i := 0
+	for _, r := range str {
+		if i == index {
+			return r, nil
+		}
+		i++
+	}
+
+	return 0, errOutOfBounds
+}
+
+// GraphemeAt returns the index'th grapheme of str, as split by Graphemes.
+func GraphemeAt(str string, index int) (string, error) {
+	graphemes := Graphemes(str)
+
+	if index < 0 || index >= len(graphemes) {
+		return "", errOutOfBounds
+	}
+
+	return graphemes[index], nil
+}
+
+// GraphemeCount returns the number of graphemes in str.
+func GraphemeCount(str string) int {
+	return len(Graphemes(str))
+}
+
+// Graphemes splits str into user-perceived characters, so that an accented
+// letter written with a combining mark, or an emoji built from several runes,
+// is kept whole. It follows the main rules for extended grapheme clusters:
+// combining marks, variation selectors, emoji modifiers and tags extend the
+// character before them, a zero width joiner joins the characters either side
+// of it, regional indicators pair up into flags, and "\r\n" stays together.
+// Hangul syllables written as separate jamo aren't joined.
+func Graphemes(str string) []string {
+	var graphemes []string
+
+	start := 0
+	var prev rune
+	regionalIndicators := 0
+
+	for i, r := range str {
+		joined := i > 0 && (extendsGrapheme(r) ||
+			prev == zeroWidthJoiner ||
+			(prev == '\r' && r == '\n') ||
+			(isRegionalIndicator(r) && regionalIndicators%2 == 1))
+
+		if i > 0 && !joined {
+			graphemes = append(graphemes, str[start:i])
+			start = i
+			regionalIndicators = 0
+		}
+
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		}
+
+		prev = r
+	}
+
+	if start < len(str) {
+		graphemes = append(graphemes, str[start:])
+	}
+
+	return graphemes
+}
+
+const zeroWidthJoiner = '\u200d'
+
+// extendsGrapheme reports whether r belongs with the character before it.
+func extendsGrapheme(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector) ||
+		r == zeroWidthJoiner ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // Emoji skin tone modifiers
+		(r >= 0xe0020 && r <= 0xe007f) // Tags, as used in subdivision flags
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
@@ -0,0 +1,84 @@
+package str
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCharAt(t *testing.T) {
+	if got, err := CharAt("abcde", 2); err != nil || got != "c" {
+		t.Errorf("CharAt() = %q, %v, want \"c\"", got, err)
+	}
+
+	for _, index := range []int{-1, 5} {
+		if _, err := CharAt("abcde", index); err == nil {
+			t.Errorf("CharAt(%v) error = nil, want out of bounds", index)
+		}
+	}
+}
+
+func TestRuneAt(t *testing.T) {
+	tests := []struct {
+		str     string
+		index   int
+		want    rune
+		wantErr bool
+	}{
+		{str: "abcde", index: 1, want: 'b'},
+		{str: "crème", index: 2, want: 'è'},
+		{str: "crème", index: 3, want: 'm'},
+		{str: "a🎄b", index: 2, want: 'b'},
+		{str: "crème", index: 5, wantErr: true},
+		{str: "crème", index: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := RuneAt(tt.str, tt.index)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("RuneAt(%q, %v) error = %v, wantErr %v", tt.str, tt.index, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("RuneAt(%q, %v) = %q, want %q", tt.str, tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want []string
+	}{
+		{name: "ascii", str: "abc", want: []string{"a", "b", "c"}},
+		{name: "precomposed accent", str: "café", want: []string{"c", "a", "f", "é"}},
+		{name: "combining accent", str: "cafe\u0301s", want: []string{"c", "a", "f", "e\u0301", "s"}},
+		{name: "emoji", str: "a🎄b", want: []string{"a", "🎄", "b"}},
+		{name: "skin tone", str: "👍🏽!", want: []string{"👍🏽", "!"}},
+		{name: "zero width joiner", str: "👩\u200d💻x", want: []string{"👩\u200d💻", "x"}},
+		{name: "variation selector", str: "❤\ufe0f?", want: []string{"❤\ufe0f", "?"}},
+		{name: "flags", str: "🇫🇷🇩🇪", want: []string{"🇫🇷", "🇩🇪"}},
+		{name: "crlf", str: "a\r\nb", want: []string{"a", "\r\n", "b"}},
+		{name: "empty", str: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Graphemes(tt.str); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graphemes(%q) = %q, want %q", tt.str, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphemeAt(t *testing.T) {
+	if got, err := GraphemeAt("cafe\u0301s", 3); err != nil || got != "e\u0301" {
+		t.Errorf("GraphemeAt() = %q, %v, want %q", got, err, "e\u0301")
+	}
+
+	if _, err := GraphemeAt("🇫🇷🇩🇪", 2); err == nil {
+		t.Error("GraphemeAt() error = nil, want out of bounds")
+	}
+
+	if got := GraphemeCount("👩\u200d💻 🇫🇷"); got != 3 {
+		t.Errorf("GraphemeCount() = %v, want 3", got)
+	}
+}